@@ -3,13 +3,44 @@ package main
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ow-api/ovrstat/service"
 )
 
 func main() {
+	cfg := service.DefaultConfig
+	cfg.Port = getenv("PORT", cfg.Port)              // The port the server will run on
+	cfg.GRPCPort = getenv("GRPC_PORT", cfg.GRPCPort) // The port of the gRPC API, disabled when empty
+
+	// Outbound rate limit, see service.Config for details
+	cfg.UpstreamRate = getenvFloat("UPSTREAM_RATE", cfg.UpstreamRate)
+	cfg.UpstreamBurst = getenvInt("UPSTREAM_BURST", cfg.UpstreamBurst)
+	cfg.UpstreamQueueTimeout = getenvDuration("UPSTREAM_QUEUE_TIMEOUT", cfg.UpstreamQueueTimeout)
+
+	// Inbound API keys and rate limits, see service.Config for details
+	cfg.APIKeysFile = getenv("API_KEYS_FILE", cfg.APIKeysFile)
+	cfg.TrustedProxies = getenvList("TRUSTED_PROXIES", cfg.TrustedProxies)
+	cfg.AnonymousRate = getenvFloat("ANONYMOUS_RATE", cfg.AnonymousRate)
+	cfg.AnonymousBurst = getenvInt("ANONYMOUS_BURST", cfg.AnonymousBurst)
+	cfg.KeyRate = getenvFloat("KEY_RATE", cfg.KeyRate)
+	cfg.KeyBurst = getenvInt("KEY_BURST", cfg.KeyBurst)
+
+	// Cache configuration, see service.Config for details
+	cfg.CacheBackend = getenv("CACHE_BACKEND", cfg.CacheBackend)
+	cfg.CachePath = getenv("CACHE_PATH", cfg.CachePath)
+	cfg.CacheURL = getenv("CACHE_URL", cfg.CacheURL)
+	cfg.CacheSize = getenvInt("CACHE_SIZE", cfg.CacheSize)
+	cfg.CacheTTL = getenvDuration("CACHE_TTL", cfg.CacheTTL)
+	cfg.NotFoundTTL = getenvDuration("CACHE_NOT_FOUND_TTL", cfg.NotFoundTTL)
+	cfg.PrivateTTL = getenvDuration("CACHE_PRIVATE_TTL", cfg.PrivateTTL)
+	cfg.StaleWhileRevalidate = getenvDuration("CACHE_STALE_WHILE_REVALIDATE", cfg.StaleWhileRevalidate)
+	cfg.StaleIfError = getenvDuration("CACHE_STALE_IF_ERROR", cfg.StaleIfError)
+
 	// Start a new service
-	service.Start(getenv("PORT", "8080")) // The port the server will run on
+	service.StartWithConfig(cfg)
 }
 
 // getenv attempts to retrieve and return a variable from the environment. If it
@@ -23,3 +54,63 @@ func getenv(key string, def ...string) string {
 	}
 	return def[0]
 }
+
+// getenvInt retrieves an integer from the environment, crashing if it isn't one
+// and failing over to the passed default value if it isn't set
+func getenvInt(key string, def int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("%s is not a valid integer: %s", key, err)
+	}
+	return i
+}
+
+// getenvFloat retrieves a number from the environment, crashing if it isn't one
+// and failing over to the passed default value if it isn't set
+func getenvFloat(key string, def float64) float64 {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Fatalf("%s is not a valid number: %s", key, err)
+	}
+	return f
+}
+
+// getenvList retrieves a comma separated list from the environment, failing
+// over to the passed default value if it isn't set
+func getenvList(key string, def []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+
+	var list []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// getenvDuration retrieves a duration (such as "10m") from the environment,
+// crashing if it isn't one and failing over to the passed default value if it
+// isn't set
+func getenvDuration(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("%s is not a valid duration: %s", key, err)
+	}
+	return d
+}
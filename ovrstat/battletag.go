@@ -0,0 +1,97 @@
+package ovrstat
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BattleTag is a normalized player battletag. Discriminator is empty when only
+// a name was given
+type BattleTag struct {
+	Name          string
+	Discriminator string
+}
+
+// ParseBattleTag normalizes a battletag written as Name#1234, Name-1234, a URL
+// encoded form of either, or a bare name. Names are normalized to Unicode NFC
+// so the composed and decomposed forms of accented names are equal
+func ParseBattleTag(tag string) (BattleTag, error) {
+	if strings.Contains(tag, "%") {
+		unescaped, err := url.PathUnescape(tag)
+		if err != nil {
+			return BattleTag{}, ErrInvalidBattleTag
+		}
+		tag = unescaped
+	}
+
+	tag = norm.NFC.String(strings.TrimSpace(tag))
+
+	var bt BattleTag
+
+	// Names can't contain either separator, so only the last one counts
+	if i := strings.LastIndexAny(tag, "#-"); i >= 0 {
+		bt.Name, bt.Discriminator = tag[:i], tag[i+1:]
+
+		if bt.Discriminator == "" || strings.IndexFunc(bt.Discriminator, isNotDigit) >= 0 {
+			return BattleTag{}, ErrInvalidBattleTag
+		}
+	} else {
+		bt.Name = tag
+	}
+
+	if bt.Name == "" || strings.IndexFunc(bt.Name, isInvalidNameRune) >= 0 {
+		return BattleTag{}, ErrInvalidBattleTag
+	}
+
+	return bt, nil
+}
+
+// String returns the canonical Name#1234 form of the battletag
+func (bt BattleTag) String() string {
+	if bt.Discriminator == "" {
+		return bt.Name
+	}
+	return bt.Name + "#" + bt.Discriminator
+}
+
+// Key returns the case folded form of the battletag, identical for every
+// spelling of the same account
+func (bt BattleTag) Key() string {
+	return cases.Fold().String(bt.String())
+}
+
+// Matches reports whether the passed battletag, as returned by the search API,
+// belongs to this battletag. A battletag without a discriminator matches every
+// account with the same name
+func (bt BattleTag) Matches(battleTag string) bool {
+	other, err := ParseBattleTag(battleTag)
+	if err != nil {
+		return false
+	}
+
+	if bt.Discriminator != "" && bt.Discriminator != other.Discriminator {
+		return false
+	}
+
+	return cases.Fold().String(bt.Name) == cases.Fold().String(other.Name)
+}
+
+// searchQuery returns the form of the battletag the search API expects
+func (bt BattleTag) searchQuery() string {
+	if bt.Discriminator == "" {
+		return bt.Name
+	}
+	return bt.Name + "-" + bt.Discriminator
+}
+
+func isNotDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+func isInvalidNameRune(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r) || r == '/' || r == '%'
+}
@@ -0,0 +1,61 @@
+package ovrstat
+
+import "testing"
+
+func TestParseBattleTag(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  error
+	}{
+		{"Viz#1213", "Viz#1213", nil},
+		{"Viz-1213", "Viz#1213", nil},
+		{"Viz%231213", "Viz#1213", nil},
+		{" Viz-1213 ", "Viz#1213", nil},
+		{"Viz", "Viz", nil},
+		{"Zoé-2187", "Zoé#2187", nil},
+		{"Zoe\u0301-2187", "Zoé#2187", nil},
+		{"Viz#", "", ErrInvalidBattleTag},
+		{"Viz-12a3", "", ErrInvalidBattleTag},
+		{"#1213", "", ErrInvalidBattleTag},
+		{"Viz 2-1213", "", ErrInvalidBattleTag},
+		{"Viz%2", "", ErrInvalidBattleTag},
+	}
+
+	for _, tt := range tests {
+		bt, err := ParseBattleTag(tt.in)
+		if err != tt.err {
+			t.Errorf("ParseBattleTag(%q) error = %v, want %v", tt.in, err, tt.err)
+			continue
+		}
+
+		if err == nil && bt.String() != tt.want {
+			t.Errorf("ParseBattleTag(%q) = %q, want %q", tt.in, bt, tt.want)
+		}
+	}
+}
+
+func TestBattleTagMatches(t *testing.T) {
+	tests := []struct {
+		tag, battleTag string
+		want           bool
+	}{
+		{"viz-1213", "Viz#1213", true},
+		{"VIZ#1213", "Viz#1213", true},
+		{"Viz-1213", "Viz#12130", false},
+		{"Viz-1213", "Vizz#1213", false},
+		{"Viz", "Viz#1213", true},
+		{"ZOÉ-2187", "zoé#2187", true},
+	}
+
+	for _, tt := range tests {
+		bt, err := ParseBattleTag(tt.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := bt.Matches(tt.battleTag); got != tt.want {
+			t.Errorf("%q.Matches(%q) = %v, want %v", tt.tag, tt.battleTag, got, tt.want)
+		}
+	}
+}
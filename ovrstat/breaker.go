@@ -0,0 +1,156 @@
+package ovrstat
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BreakerState is the state of a client's circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen fails every request without contacting the Overwatch site
+	BreakerOpen
+
+	// BreakerHalfOpen lets a single probe request through to find out whether
+	// the Overwatch site has recovered
+	BreakerHalfOpen
+)
+
+// String returns the name of the state
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// MarshalText encodes the state as its name
+func (s BreakerState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a state from its name
+func (s *BreakerState) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "closed":
+		*s = BreakerClosed
+	case "open":
+		*s = BreakerOpen
+	case "half-open":
+		*s = BreakerHalfOpen
+	default:
+		return errors.Errorf("Unknown breaker state %q", text)
+	}
+	return nil
+}
+
+// BreakerStatus describes a client's circuit breaker
+type BreakerStatus struct {
+	State BreakerState `json:"state"`
+
+	// Failures is the number of consecutive failed requests
+	Failures int `json:"failures"`
+
+	// RetryAt is when an open breaker lets a probe request through
+	RetryAt *time.Time `json:"retryAt,omitempty"`
+}
+
+// breaker is a circuit breaker that opens after a number of consecutive
+// upstream failures, fast-failing requests until a cooldown has passed. A nil
+// breaker lets every request through
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     BreakerState
+	openedAt  time.Time
+	probing   bool
+}
+
+// newBreaker creates and returns a new, closed breaker. A threshold of 0 or
+// less disables it
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns an error when a request to the passed url mustn't be made
+func (b *breaker) allow(url string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		if wait := time.Until(b.openedAt.Add(b.cooldown)); wait > 0 {
+			return &UpstreamError{URL: url, RetryAfter: wait, Kind: ErrUpstreamUnavailable, Err: ErrCircuitOpen}
+		}
+		b.state = BreakerHalfOpen
+	}
+
+	if b.state == BreakerHalfOpen {
+		if b.probing {
+			return &UpstreamError{URL: url, Kind: ErrUpstreamUnavailable, Err: ErrCircuitOpen}
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record records the outcome of an allowed request. Requests that neither
+// succeeded nor failed, such as cancelled ones, don't count either way
+func (b *breaker) record(failed, counts bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	switch {
+	case !counts:
+	case !failed:
+		b.failures = 0
+		b.state = BreakerClosed
+	default:
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.state = BreakerOpen
+			b.openedAt = time.Now()
+		}
+	}
+}
+
+// status returns the current status of the breaker
+func (b *breaker) status() BreakerStatus {
+	if b == nil {
+		return BreakerStatus{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := BreakerStatus{State: b.state, Failures: b.failures}
+	if b.state == BreakerOpen {
+		retryAt := b.openedAt.Add(b.cooldown)
+		s.RetryAt = &retryAt
+	}
+	return s
+}
@@ -0,0 +1,220 @@
+package ovrstat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Get returns the typed value of a single stat. The category and key are the
+// names used in the JSON output, such as Get("game", "timePlayed")
+func (cs *CareerStats) Get(category, key string) (StatValue, bool) {
+	if cs == nil {
+		return StatValue{}, false
+	}
+
+	if cs.Values != nil {
+		v, ok := cs.Values[category][key]
+		return v, ok
+	}
+
+	// Stats that weren't parsed by this package (decoded from JSON for
+	// example) are typed from their map values instead
+	v, ok := cs.category(category)[key]
+	if !ok {
+		return StatValue{}, false
+	}
+	return statValueOf(v), true
+}
+
+// Typed returns every stat in typed form, keyed by category and stat key
+func (cs *CareerStats) Typed() map[string]map[string]StatValue {
+	typed := make(map[string]map[string]StatValue)
+
+	if cs == nil {
+		return typed
+	}
+
+	for _, category := range careerCategories {
+		for key := range cs.category(category) {
+			if typed[category] == nil {
+				typed[category] = make(map[string]StatValue)
+			}
+			typed[category][key], _ = cs.Get(category, key)
+		}
+	}
+
+	return typed
+}
+
+// careerCategories are the JSON names of every CareerStats category
+var careerCategories = []string{
+	"assists",
+	"average",
+	"best",
+	"combat",
+	"deaths",
+	"heroSpecific",
+	"game",
+	"matchAwards",
+}
+
+// category returns the map based bucket of the passed category
+func (cs *CareerStats) category(name string) map[string]interface{} {
+	switch name {
+	case "assists":
+		return cs.Assists
+	case "average":
+		return cs.Average
+	case "best":
+		return cs.Best
+	case "combat":
+		return cs.Combat
+	case "deaths":
+		return cs.Deaths
+	case "heroSpecific":
+		return cs.HeroSpecific
+	case "game":
+		return cs.Game
+	case "matchAwards":
+		return cs.MatchAwards
+	}
+	return nil
+}
+
+// setValue stores the typed form of a parsed stat
+func (cs *CareerStats) setValue(category, key string, v StatValue) {
+	if cs.Values == nil {
+		cs.Values = make(StatValues)
+	}
+
+	if cs.Values[category] == nil {
+		cs.Values[category] = make(map[string]StatValue)
+	}
+
+	cs.Values[category][key] = v
+}
+
+// parseStatValue types a stat from the text displayed on the career page
+func parseStatValue(display string) StatValue {
+	val := strings.Replace(strings.TrimSpace(display), ",", "", -1)
+
+	if f, ok := parsePercentage(val); ok {
+		return StatValue{Kind: StatPercentage, Value: f, Display: display}
+	}
+
+	if d, ok := parseDuration(val); ok {
+		return StatValue{Kind: StatDuration, Value: d.Seconds(), Display: display}
+	}
+
+	if i, err := strconv.Atoi(val); err == nil {
+		return StatValue{Kind: StatCount, Value: float64(i), Display: display}
+	}
+
+	if f, err := strconv.ParseFloat(val, 64); err == nil {
+		return StatValue{Kind: StatRatio, Value: f, Display: display}
+	}
+
+	return StatValue{Kind: StatText, Display: display}
+}
+
+// statValueOf types a stat from its map value as produced by parseType
+func statValueOf(v interface{}) StatValue {
+	switch v := v.(type) {
+	case int:
+		return StatValue{Kind: StatCount, Value: float64(v), Display: strconv.Itoa(v)}
+	case float64:
+		// Decoded JSON numbers are always floats, so whole ones are counts
+		kind := StatRatio
+		if v == float64(int64(v)) {
+			kind = StatCount
+		}
+		return StatValue{Kind: kind, Value: v, Display: strconv.FormatFloat(v, 'f', -1, 64)}
+	case string:
+		return parseStatValue(v)
+	}
+	return StatValue{Kind: StatText}
+}
+
+// Duration returns the value of a duration stat as a time.Duration, or zero if
+// the stat isn't a duration
+func (v StatValue) Duration() time.Duration {
+	if v.Kind != StatDuration {
+		return 0
+	}
+	return time.Duration(v.Value * float64(time.Second))
+}
+
+// parsePercentage parses a percentage such as "45%" or "12.5%" to its number
+func parsePercentage(val string) (float64, bool) {
+	val = strings.TrimSpace(val)
+
+	if !strings.HasSuffix(val, "%") {
+		return 0, false
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(val, "%")), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// durationUnits maps the unit words used by the career pages to durations
+var durationUnits = map[string]time.Duration{
+	"second":  time.Second,
+	"seconds": time.Second,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+}
+
+// parseDuration parses the durations shown on the career pages, which are
+// either clock formatted (HH:MM:SS or MM:SS, hours not wrapping at a day) or
+// written out with units such as "2 hours" or "1 hour 5 minutes"
+func parseDuration(val string) (time.Duration, bool) {
+	val = strings.TrimSpace(val)
+
+	if strings.Contains(val, ":") {
+		var secs int64
+
+		for _, part := range strings.Split(val, ":") {
+			n, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || n < 0 {
+				return 0, false
+			}
+			secs = secs*60 + n
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	fields := strings.Fields(strings.ToLower(val))
+
+	// Unit durations always come in number and unit pairs
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return 0, false
+	}
+
+	var d time.Duration
+
+	for i := 0; i < len(fields); i += 2 {
+		n, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+
+		unit, ok := durationUnits[strings.TrimRightFunc(fields[i+1], unicode.IsPunct)]
+		if !ok {
+			return 0, false
+		}
+
+		d += time.Duration(n * float64(unit))
+	}
+
+	return d, true
+}
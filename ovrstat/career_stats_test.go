@@ -0,0 +1,96 @@
+package ovrstat
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCareerStatsGet(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stats, err := ParseProfile(f, PlatformPC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed := stats.QuickPlayStats.CareerStats["allHeroes"]
+
+	// The same stats decoded from JSON are typed from their map values
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded CareerStats
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		category, key string
+		kind          StatKind
+		value         float64
+		display       string
+	}{
+		{"assists", "healingDone", StatCount, 1203440, "1,203,440"},
+		{"average", "eliminationsAvgPer10Min", StatRatio, 15.41, "15.41"},
+		{"average", "timeSpentOnFireAvgPer10Min", StatDuration, 72, "01:12"},
+		{"combat", "weaponAccuracy", StatPercentage, 39, "39%"},
+		{"game", "timePlayed", StatDuration, 60056, "16:40:56"},
+	}
+
+	for _, cs := range []*CareerStats{parsed, &decoded} {
+		for _, tt := range tests {
+			v, ok := cs.Get(tt.category, tt.key)
+			if !ok {
+				t.Errorf("%s.%s not found", tt.category, tt.key)
+				continue
+			}
+
+			if v.Kind != tt.kind || v.Value != tt.value {
+				t.Errorf("%s.%s = %+v, want %s %v", tt.category, tt.key, v, tt.kind, tt.value)
+			}
+
+			// Only the parsed stats retain the original text
+			if cs == parsed && v.Display != tt.display {
+				t.Errorf("%s.%s display = %q, want %q", tt.category, tt.key, v.Display, tt.display)
+			}
+		}
+	}
+
+	if _, ok := parsed.Get("game", "missing"); ok {
+		t.Error("expected a missing stat not to be found")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"12:34:56", 12*time.Hour + 34*time.Minute + 56*time.Second, true},
+		{"112:00:05", 112*time.Hour + 5*time.Second, true},
+		{"45:10", 45*time.Minute + 10*time.Second, true},
+		{"2 hours", 2 * time.Hour, true},
+		{"1 hour 5 minutes", time.Hour + 5*time.Minute, true},
+		{"30 seconds", 30 * time.Second, true},
+		{"--", 0, false},
+		{"12:ab", 0, false},
+		{"5 parsecs", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseDuration(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseDuration(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package ovrstat
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// siteURL is the root of the Overwatch site that all default URLs are built on
+	siteURL = "https://overwatch.blizzard.com"
+
+	// defaultLocale is the locale the parser understands the stat labels of
+	defaultLocale = "en-us"
+
+	// defaultQueueTimeout is how long a rate limited request may wait for its
+	// turn by default
+	defaultQueueTimeout = 5 * time.Second
+
+	// Default retry and circuit breaker settings, see WithRetries and
+	// WithCircuitBreaker
+	defaultRetries          = 2
+	defaultRetryBaseDelay   = 250 * time.Millisecond
+	defaultRetryMaxDelay    = 5 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// Client performs lookups against the Overwatch search API and career pages.
+// A zero Client is not usable, create one with NewClient
+type Client struct {
+	httpClient *http.Client
+	careerURL  string
+	searchURL  string
+	locale     string
+	header     http.Header
+
+	limiter      *rate.Limiter
+	queueTimeout time.Duration
+
+	retries        int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	breaker        *breaker
+}
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// DefaultClient is the Client used by the package level lookup functions
+var DefaultClient = NewClient()
+
+// NewClient creates and returns a new Client configured with the passed
+// options. Any URL that isn't explicitly set is built from the locale
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient:   http.DefaultClient,
+		locale:       defaultLocale,
+		header:       make(http.Header),
+		queueTimeout: defaultQueueTimeout,
+
+		retries:        defaultRetries,
+		retryBaseDelay: defaultRetryBaseDelay,
+		retryMaxDelay:  defaultRetryMaxDelay,
+		breaker:        newBreaker(defaultBreakerThreshold, defaultBreakerCooldown),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.careerURL == "" {
+		c.careerURL = siteURL + "/" + c.locale + "/career"
+	}
+
+	if c.searchURL == "" {
+		c.searchURL = siteURL + "/" + c.locale + "/search/account-by-name/"
+	}
+
+	return c
+}
+
+// WithHTTPClient sets the http.Client used for every outbound request, which
+// is where timeouts and custom transports are configured
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// WithCareerURL sets the base URL player profiles are retrieved from, for
+// example "https://overwatch.blizzard.com/en-us/career"
+func WithCareerURL(u string) Option {
+	return func(c *Client) {
+		c.careerURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithSearchURL sets the URL player names are appended to when searching, for
+// example "https://overwatch.blizzard.com/en-us/search/account-by-name/"
+func WithSearchURL(u string) Option {
+	return func(c *Client) {
+		if u != "" && !strings.HasSuffix(u, "/") {
+			u += "/"
+		}
+		c.searchURL = u
+	}
+}
+
+// WithLocale sets the site locale (such as "en-us") used to build the default
+// URLs. Note that stat keys are derived from the page labels, so anything other
+// than the default locale will produce localized keys
+func WithLocale(locale string) Option {
+	return func(c *Client) {
+		if locale != "" {
+			c.locale = strings.ToLower(locale)
+		}
+	}
+}
+
+// WithHeader adds a header that is sent with every outbound request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every outbound request
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.header.Set("User-Agent", ua)
+	}
+}
+
+// WithRateLimit limits outbound requests to perSecond on average, with
+// bursts of up to burst requests. Requests over the limit are queued, see
+// WithQueueTimeout. By default requests aren't limited
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		if perSecond > 0 {
+			c.limiter = rate.NewLimiter(rate.Limit(perSecond), burst)
+		}
+	}
+}
+
+// WithQueueTimeout sets how long a request held back by the rate limit may
+// wait for its turn before failing with ErrQueueFull, 5 seconds by default
+func WithQueueTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.queueTimeout = d
+	}
+}
+
+// wait blocks until the rate limit lets a request to the passed url through.
+// When that would take longer than the queue timeout or the context allows
+// it fails right away with ErrQueueFull, reporting when to retry
+func (c *Client) wait(ctx context.Context, url string) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	r := c.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return &UpstreamError{URL: url, Kind: ErrQueueFull}
+	}
+
+	delay := r.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); delay > c.queueTimeout || (ok && now.Add(delay).After(deadline)) {
+		r.CancelAt(now)
+		return &UpstreamError{URL: url, RetryAfter: delay, Kind: ErrQueueFull}
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// WithRetries sets how many times a request failing with a transport error or
+// a retryable status is retried, waiting a random delay up to baseDelay doubled
+// on every attempt and capped at maxDelay. By default requests are retried
+// twice from 250ms up to 5 seconds, 0 retries disables retrying
+func WithRetries(retries int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryBaseDelay = baseDelay
+		c.retryMaxDelay = maxDelay
+	}
+}
+
+// WithCircuitBreaker sets how many consecutive failed requests open the
+// circuit breaker, fast-failing every request with ErrCircuitOpen until the
+// cooldown has passed. By default it opens after 5 failures for 30 seconds, a
+// threshold of 0 disables it
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breaker = newBreaker(threshold, cooldown)
+	}
+}
+
+// BreakerStatus returns the status of the client's circuit breaker
+func (c *Client) BreakerStatus() BreakerStatus {
+	return c.breaker.status()
+}
+
+// get performs a GET request on the passed url with the clients headers, bound
+// to the passed context, the rate limit and the circuit breaker, retrying it
+// when it fails transiently
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	if err := c.breaker.allow(url); err != nil {
+		return nil, err
+	}
+
+	res, err := c.getWithRetries(ctx, url)
+
+	// Requests abandoned by the caller or held back by the rate limit don't
+	// say anything about the health of the Overwatch site
+	counts := ctx.Err() == nil && !errors.Is(err, ErrQueueFull)
+	c.breaker.record(err != nil || res.StatusCode >= http.StatusInternalServerError, counts)
+
+	return res, err
+}
+
+// do performs a single GET request on the passed url with the clients headers
+func (c *Client) do(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range c.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+
+	return c.httpClient.Do(req)
+}
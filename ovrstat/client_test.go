@@ -0,0 +1,413 @@
+package ovrstat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientPrivatePlayer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/Viz-1213" {
+			t.Errorf("unexpected search path %q", r.URL.Path)
+		}
+
+		if ua := r.Header.Get("User-Agent"); ua != "ovrstat-test" {
+			t.Errorf("unexpected user agent %q", ua)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"battleTag":"Viz#1213","isPublic":false,"url":"abc"}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(
+		WithHTTPClient(srv.Client()),
+		WithSearchURL(srv.URL+"/search"),
+		WithCareerURL(srv.URL+"/career"),
+		WithUserAgent("ovrstat-test"),
+	)
+
+	stats, err := c.Stats(PlatformPC, "Viz-1213")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !stats.Private {
+		t.Error("expected a private profile")
+	}
+}
+
+func TestClientStatsContextCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithHTTPClient(srv.Client()), WithSearchURL(srv.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.StatsContext(ctx, PlatformPC, "Viz-1213"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClientAmbiguousPlayer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"battleTag":"Viz#1213","isPublic":true,"url":"a"},{"battleTag":"Viz#2222","isPublic":true,"url":"b"}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithHTTPClient(srv.Client()), WithSearchURL(srv.URL))
+
+	players, err := c.Search("Viz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(players) != 2 {
+		t.Fatalf("expected 2 players, got %d", len(players))
+	}
+
+	_, err = c.Stats(PlatformPC, "Viz")
+
+	var ambiguous *AmbiguousPlayerError
+	if !errors.As(err, &ambiguous) || !errors.Is(err, ErrAmbiguousPlayer) {
+		t.Fatalf("expected an ambiguous player error, got %v", err)
+	}
+
+	if len(ambiguous.Candidates) != 2 {
+		t.Errorf("expected 2 candidates, got %d", len(ambiguous.Candidates))
+	}
+}
+
+func TestClientMatchesDiscriminator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first search result isn't the requested account
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"battleTag":"Viz#9999","isPublic":true,"url":"a"},{"battleTag":"viz#1213","isPublic":false,"url":"b"}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithHTTPClient(srv.Client()), WithSearchURL(srv.URL))
+
+	stats, err := c.Stats(PlatformPC, "VIZ%231213")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !stats.Private {
+		t.Error("expected the private viz#1213 profile")
+	}
+
+	if _, err := c.Stats(PlatformPC, "Viz-4444"); err != ErrPlayerNotFound {
+		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	}
+}
+
+func TestClientUpstreamErrors(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	garbage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>not json</html>`))
+	}))
+	defer garbage.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name string
+		c    *Client
+		want error
+	}{
+		{"timeout", NewClient(WithHTTPClient(&http.Client{Timeout: 10 * time.Millisecond}), WithSearchURL(slow.URL)), ErrTimeout},
+		{"markup", NewClient(WithSearchURL(garbage.URL)), ErrMarkupChanged},
+		{"unavailable", NewClient(WithSearchURL(closed.URL)), ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.c.Stats(PlatformPC, "Viz-1213")
+
+		var upstream *UpstreamError
+		if !errors.Is(err, tt.want) || !errors.As(err, &upstream) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	// One request per 50ms, queueing for up to 100ms
+	c := NewClient(
+		WithHTTPClient(srv.Client()),
+		WithSearchURL(srv.URL),
+		WithRateLimit(20, 1),
+		WithQueueTimeout(100*time.Millisecond),
+	)
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := c.Search("Viz"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("expected the second request to be queued, took %s", d)
+	}
+
+	// Three requests are queued ahead of the last one
+	for i := 0; i < 3; i++ {
+		go c.Search("Viz")
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	_, err := c.Search("Viz")
+
+	var upstream *UpstreamError
+	if !errors.Is(err, ErrQueueFull) || !errors.As(err, &upstream) || upstream.RetryAfter <= 100*time.Millisecond {
+		t.Fatalf("expected ErrQueueFull with a retry delay, got %v", err)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithHTTPClient(srv.Client()), WithSearchURL(srv.URL), WithRetries(2, time.Millisecond, time.Second))
+
+	if _, err := c.Search("Viz"); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	var requests int32
+	var healthy atomic.Bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(
+		WithHTTPClient(srv.Client()),
+		WithSearchURL(srv.URL),
+		WithRetries(0, 0, 0),
+		WithCircuitBreaker(2, 50*time.Millisecond),
+	)
+
+	for i := 0; i < 2; i++ {
+		c.Search("Viz")
+	}
+
+	if s := c.BreakerStatus(); s.State != BreakerOpen || s.Failures != 2 || s.RetryAt == nil {
+		t.Fatalf("expected an open breaker, got %+v", s)
+	}
+
+	_, err := c.Search("Viz")
+
+	var upstream *UpstreamError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.Is(err, ErrUpstreamUnavailable) || !errors.As(err, &upstream) || upstream.RetryAfter <= 0 {
+		t.Fatalf("expected a fast-failed lookup, got %v", err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected the open breaker to hold back requests, got %d", n)
+	}
+
+	// After the cooldown a successful probe closes the breaker again
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+
+	if _, err := c.Search("Viz"); err != nil {
+		t.Fatal(err)
+	}
+
+	if s := c.BreakerStatus(); s.State != BreakerClosed || s.Failures != 0 {
+		t.Errorf("expected a closed breaker, got %+v", s)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := parseRetryAfter(tt.in, now); got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestClientUpstreamResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		search     http.HandlerFunc
+		profile    http.HandlerFunc
+		want       error
+		retryAfter time.Duration
+	}{
+		{
+			name: "search rate limited",
+			search: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			want:       ErrUpstreamRateLimited,
+			retryAfter: 30 * time.Second,
+		},
+		{
+			name: "search maintenance",
+			search: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`<html><h1>Scheduled Maintenance</h1></html>`))
+			},
+			want: ErrUpstreamMaintenance,
+		},
+		{
+			name: "search html",
+			search: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(`<html>[]</html>`))
+			},
+			want: ErrMarkupChanged,
+		},
+		{
+			name: "profile not found",
+			profile: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusNotFound)
+			},
+			want: ErrPlayerNotFound,
+		},
+		{
+			name: "profile maintenance",
+			profile: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`<p>Overwatch is undergoing maintenance</p>`))
+			},
+			want: ErrUpstreamMaintenance,
+		},
+		{
+			name: "profile maintenance with 200",
+			profile: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(`<html><head><title>Overwatch - Scheduled Maintenance</title></head></html>`))
+			},
+			want: ErrUpstreamMaintenance,
+		},
+		{
+			name: "profile missing",
+			profile: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(`<html><head><title>Overwatch</title></head><h1>Profile Not Found</h1></html>`))
+			},
+			want: ErrPlayerNotFound,
+		},
+		{
+			name: "profile markup changed",
+			profile: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(`<html><head><title>Overwatch</title></head><div class="Profile-header"></div></html>`))
+			},
+			want: ErrMarkupChanged,
+		},
+		{
+			name: "profile bad gateway",
+			profile: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			want: ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/search/", func(w http.ResponseWriter, r *http.Request) {
+			if tt.search != nil {
+				tt.search(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"battleTag":"Viz#1213","isPublic":true,"url":"viz-1213"}]`))
+		})
+		if tt.profile != nil {
+			mux.HandleFunc("/career/", tt.profile)
+		}
+
+		srv := httptest.NewServer(mux)
+
+		c := NewClient(
+			WithHTTPClient(srv.Client()),
+			WithSearchURL(srv.URL+"/search"),
+			WithCareerURL(srv.URL+"/career"),
+			WithRetries(0, 0, 0),
+		)
+
+		_, err := c.Stats(PlatformPC, "Viz-1213")
+		srv.Close()
+
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+			continue
+		}
+
+		var upstream *UpstreamError
+		if tt.want != ErrPlayerNotFound && (!errors.As(err, &upstream) || upstream.RetryAfter != tt.retryAfter) {
+			t.Errorf("%s: unexpected upstream error %#v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package ovrstat
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrPlayerNotFound is thrown when a player doesn't exist
+	ErrPlayerNotFound = errors.New("Player not found")
+
+	// ErrPlayerPrivate is thrown when a lookup needs stats that a private
+	// profile doesn't show. Stats lookups report private profiles through
+	// PlayerStats.Private instead
+	ErrPlayerPrivate = errors.New("Player profile is private")
+
+	// ErrInvalidPlatform is thrown when the passed params are incorrect
+	ErrInvalidPlatform = errors.New("Invalid platform")
+
+	// ErrPlatformNotFound is thrown when a player's profile has no stats on
+	// the requested platform
+	ErrPlatformNotFound = errors.New("Player has no stats on this platform")
+
+	// ErrInvalidBattleTag is thrown when the passed tag isn't a valid battletag
+	ErrInvalidBattleTag = errors.New("Invalid battletag")
+
+	// ErrAmbiguousPlayer is thrown when a name matches several players, the
+	// error returned is an *AmbiguousPlayerError listing them
+	ErrAmbiguousPlayer = errors.New("Ambiguous player")
+
+	// ErrUpstreamUnavailable is thrown when the Overwatch site can't be reached
+	// or fails to serve a response
+	ErrUpstreamUnavailable = errors.New("Upstream unavailable")
+
+	// ErrUpstreamMaintenance is thrown, wrapped in ErrUpstreamUnavailable,
+	// when the Overwatch site serves its maintenance page
+	ErrUpstreamMaintenance = errors.New("Upstream down for maintenance")
+
+	// ErrUpstreamRateLimited is thrown when the Overwatch site is rate limiting
+	// our requests
+	ErrUpstreamRateLimited = errors.New("Upstream rate limited")
+
+	// ErrMarkupChanged is thrown when a response from the Overwatch site is in
+	// a format the package doesn't understand
+	ErrMarkupChanged = errors.New("Upstream markup changed")
+
+	// ErrTimeout is thrown when a request to the Overwatch site times out
+	ErrTimeout = errors.New("Upstream timeout")
+
+	// ErrQueueFull is thrown when the client's rate limit would hold a request
+	// back for longer than its queue timeout or the context allows
+	ErrQueueFull = errors.New("Upstream request queue full")
+
+	// ErrCircuitOpen is thrown, wrapped in ErrUpstreamUnavailable, while the
+	// client's circuit breaker fast-fails requests after repeated failures
+	ErrCircuitOpen = errors.New("Upstream circuit open")
+)
+
+// AmbiguousPlayerError is returned when a name without a discriminator matches
+// several accounts. Candidates holds every matching account so the caller can
+// retry with a full battletag
+type AmbiguousPlayerError struct {
+	Name       string
+	Candidates []Player
+}
+
+// Error implements the error interface
+func (e *AmbiguousPlayerError) Error() string {
+	return fmt.Sprintf("%s matches %d players", e.Name, len(e.Candidates))
+}
+
+// Is reports whether the error matches ErrAmbiguousPlayer
+func (e *AmbiguousPlayerError) Is(target error) bool {
+	return target == ErrAmbiguousPlayer
+}
+
+// UpstreamError is returned when a request to the Overwatch site fails. Kind
+// is one of the upstream errors (ErrUpstreamUnavailable, ErrUpstreamRateLimited,
+// ErrMarkupChanged, ErrTimeout or ErrQueueFull) and errors.Is matches both it
+// and the underlying Err
+type UpstreamError struct {
+	URL        string
+	StatusCode int
+	RetryAfter time.Duration
+	Kind       error
+	Err        error
+}
+
+// Error implements the error interface
+func (e *UpstreamError) Error() string {
+	msg := e.Kind.Error()
+
+	// Errors of parsed pages have no URL when they weren't fetched
+	if e.URL != "" {
+		msg += ": " + e.URL
+	}
+
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns both the kind and the underlying error
+func (e *UpstreamError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// upstreamErr classifies a failed request to the passed url. Cancellation by
+// the caller isn't an upstream failure and is returned as is, as are errors
+// that are already classified
+func upstreamErr(url string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return errors.Wrap(err, "Request cancelled")
+	}
+
+	if _, ok := err.(*UpstreamError); ok {
+		return err
+	}
+
+	kind := ErrUpstreamUnavailable
+
+	var netErr net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kind = ErrTimeout
+	}
+
+	return &UpstreamError{URL: url, Kind: kind, Err: err}
+}
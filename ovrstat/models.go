@@ -1,6 +1,10 @@
 package ovrstat
 
-import "github.com/PuerkitoBio/goquery"
+import (
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
 
 // PlayerStats holds all stats on a specified Overwatch player
 type PlayerStats struct {
@@ -15,6 +19,27 @@ type PlayerStats struct {
 	QuickPlayStats   QuickPlayStatsCollection   `json:"quickPlayStats"`
 	CompetitiveStats CompetitiveStatsCollection `json:"competitiveStats"`
 	Private          bool                       `json:"private"`
+	Warnings         []ParseWarning             `json:"warnings,omitempty"`
+
+	// Drift lists every way the career page differed from the layout the
+	// parser expects. It's meant for monitoring and isn't served to clients
+	Drift []Drift `json:"-"`
+}
+
+// ParseWarning describes a part of the career page that couldn't be parsed.
+// The stats it would have populated are left at their zero values
+type ParseWarning struct {
+	Selector string `json:"selector"`
+	Field    string `json:"field"`
+	Reason   string `json:"reason"`
+}
+
+// ProfileStats holds the stats of every platform present on a players profile,
+// keyed by platform (PlatformPC, PlatformConsole)
+type ProfileStats struct {
+	Platforms []string                `json:"platforms"`
+	Stats     map[string]*PlayerStats `json:"stats"`
+	Private   bool                    `json:"private"`
 }
 
 type Rating struct {
@@ -43,12 +68,23 @@ type QuickPlayStatsCollection struct {
 // TopHeroStats holds basic stats for each hero
 type TopHeroStats struct {
 	TimePlayed          string  `json:"timePlayed"`
+	TimePlayedSeconds   int64   `json:"timePlayedSeconds"`
 	GamesWon            int     `json:"gamesWon"`
 	WeaponAccuracy      int     `json:"weaponAccuracy"`
 	CriticalHitAccuracy int     `json:"criticalHitAccuracy"`
 	EliminationsPerLife float64 `json:"eliminationsPerLife"`
 	MultiKillBest       int     `json:"multiKillBest"`
 	ObjectiveKills      float64 `json:"objectiveKills"`
+
+	// The accuracies above are rounded to whole percents, these keep the
+	// exact percentage shown on the career page
+	WeaponAccuracyPercent      float64 `json:"weaponAccuracyPercent"`
+	CriticalHitAccuracyPercent float64 `json:"criticalHitAccuracyPercent"`
+}
+
+// TimePlayedDuration returns the time played on the hero as a time.Duration
+func (ths *TopHeroStats) TimePlayedDuration() time.Duration {
+	return time.Duration(ths.TimePlayedSeconds) * time.Second
 }
 
 // CareerStats holds very detailed stats for each hero
@@ -63,6 +99,67 @@ type CareerStats struct {
 
 	// Deaths appears to have been removed, so we hide it.
 	Deaths map[string]interface{} `json:"deaths,omitempty"`
+
+	// Values holds the typed form of every parsed stat by category and key,
+	// such as Values["game"]["timePlayed"]. The maps above hold the stats as
+	// ints, floats or strings, which can't tell a count from a duration or a
+	// percentage. It's left out of the JSON form to keep it to the categories
+	Values StatValues `json:"-"`
+}
+
+// StatValues holds typed career stats by category and stat key
+type StatValues map[string]map[string]StatValue
+
+// StatKind identifies what a career stat value measures
+type StatKind string
+
+const (
+	// StatCount is a whole number such as eliminations or games won
+	StatCount StatKind = "count"
+
+	// StatDuration is an amount of time, its value is in seconds
+	StatDuration StatKind = "duration"
+
+	// StatPercentage is a percentage, its value is the number shown (45 for 45%)
+	StatPercentage StatKind = "percentage"
+
+	// StatRatio is a fractional number such as an average per 10 minutes
+	StatRatio StatKind = "ratio"
+
+	// StatText is anything that couldn't be read as a number
+	StatText StatKind = "text"
+)
+
+// StatValue is a single career stat in typed form
+type StatValue struct {
+	Kind    StatKind `json:"kind"`
+	Value   float64  `json:"value"`
+	Display string   `json:"display"`
+}
+
+// DriftKind identifies how a career page differs from the expected layout
+type DriftKind string
+
+const (
+	// DriftUnknownCategory is a career stats category the parser doesn't know
+	DriftUnknownCategory DriftKind = "unknownCategory"
+
+	// DriftUnknownTopHeroMetric is a top heroes metric the parser doesn't know
+	DriftUnknownTopHeroMetric DriftKind = "unknownTopHeroMetric"
+
+	// DriftMissingSelector is an element the parser expects that wasn't found
+	DriftMissingSelector DriftKind = "missingSelector"
+
+	// DriftEmptySection is a section that was found but had nothing to parse
+	DriftEmptySection DriftKind = "emptySection"
+)
+
+// Drift is a single difference between a career page and the layout the
+// parser expects, found by selector and named by category or metric
+type Drift struct {
+	Kind     DriftKind `json:"kind"`
+	Selector string    `json:"selector"`
+	Name     string    `json:"name,omitempty"`
 }
 
 // Player represents a response from the search-by-name api request
@@ -1,14 +1,20 @@
 package ovrstat
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"golang.org/x/net/html"
+	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
@@ -16,10 +22,6 @@ import (
 )
 
 const (
-	baseURL = "https://overwatch.blizzard.com/en-us/career"
-
-	apiURL = "https://overwatch.blizzard.com/en-us/search/account-by-name/"
-
 	// PlatformPC is a platform for PCs (mouseKeyboard in the page)
 	PlatformPC = "pc"
 
@@ -27,101 +29,454 @@ const (
 	PlatformConsole = "console"
 )
 
-var (
-	// ErrPlayerNotFound is thrown when a player doesn't exist
-	ErrPlayerNotFound = errors.New("Player not found")
-
-	// ErrInvalidPlatform is thrown when the passed params are incorrect
-	ErrInvalidPlatform = errors.New("Invalid platform")
+// Stats retrieves player stats using the DefaultClient
+// Universal method if you don't need to differentiate it
+func Stats(platformKey, tag string) (*PlayerStats, error) {
+	return DefaultClient.StatsContext(context.Background(), platformKey, tag)
+}
 
-	// regexpPlayerTagName matches a player's name for search rather than the full tag
-	regexpPlayerTagName = regexp.MustCompile("(.*?)[#\\-]\\d+")
-)
+// StatsContext retrieves player stats using the DefaultClient, aborting the
+// lookup when the passed context is cancelled
+func StatsContext(ctx context.Context, platformKey, tag string) (*PlayerStats, error) {
+	return DefaultClient.StatsContext(ctx, platformKey, tag)
+}
 
 // Stats retrieves player stats
 // Universal method if you don't need to differentiate it
-func Stats(platformKey, tag string) (*PlayerStats, error) {
-	// Do platform key mapping
-	switch platformKey {
-	case PlatformPC:
-		platformKey = "mouseKeyboard"
-	case PlatformConsole:
-		platformKey = "controller"
+func (c *Client) Stats(platformKey, tag string) (*PlayerStats, error) {
+	return c.StatsContext(context.Background(), platformKey, tag)
+}
+
+// StatsContext retrieves player stats, propagating cancellation and deadlines
+// of the passed context to the search and profile requests
+func (c *Client) StatsContext(ctx context.Context, platformKey, tag string) (*PlayerStats, error) {
+	player, err := c.findPlayer(ctx, tag)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse the API response first
-	var ps PlayerStats
+	if !player.IsPublic {
+		return &PlayerStats{Private: true}, nil
+	}
 
-	players, err := retrievePlayers(tag)
+	res, err := c.fetchProfile(ctx, player)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	stats, err := parseProfile(res.Body, platformKey)
+	return stats, pageErr(res, err)
+}
+
+// AllStats retrieves the stats of every platform on a players profile using
+// the DefaultClient
+func AllStats(tag string) (*ProfileStats, error) {
+	return DefaultClient.AllStatsContext(context.Background(), tag)
+}
+
+// AllStatsContext retrieves the stats of every platform on a players profile
+// using the DefaultClient, aborting the lookup when the passed context is
+// cancelled
+func AllStatsContext(ctx context.Context, tag string) (*ProfileStats, error) {
+	return DefaultClient.AllStatsContext(ctx, tag)
+}
 
+// AllStats retrieves the stats of every platform on a players profile
+func (c *Client) AllStats(tag string) (*ProfileStats, error) {
+	return c.AllStatsContext(context.Background(), tag)
+}
+
+// AllStatsContext retrieves the stats of every platform on a players profile
+// from a single profile fetch, propagating cancellation and deadlines of the
+// passed context to the search and profile requests
+func (c *Client) AllStatsContext(ctx context.Context, tag string) (*ProfileStats, error) {
+	player, err := c.findPlayer(ctx, tag)
 	if err != nil {
 		return nil, err
 	}
 
-	var player *Player
+	if !player.IsPublic {
+		return &ProfileStats{
+			Platforms: []string{},
+			Stats:     make(map[string]*PlayerStats),
+			Private:   true,
+		}, nil
+	}
 
-	if len(players) == 0 {
-		// Fall back to non-exact search to try to match the user
-		tagMatch := regexpPlayerTagName.FindStringSubmatch(tag)
+	res, err := c.fetchProfile(ctx, player)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
 
-		if tagMatch == nil {
-			return nil, ErrPlayerNotFound
-		}
+	profile, err := parseAllProfiles(res.Body)
+	return profile, pageErr(res, err)
+}
 
-		players, err = retrievePlayers(tagMatch[1])
+// findPlayer resolves the passed tag to a single player using the search API.
+// The player returned always matches the requested discriminator rather than
+// just being the first search result
+func (c *Client) findPlayer(ctx context.Context, tag string) (*Player, error) {
+	bt, err := ParseBattleTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the API response first
+	players, err := c.retrievePlayers(ctx, bt.searchQuery())
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchPlayers(bt, players)
+
+	if len(matches) == 0 && bt.Discriminator != "" {
+		// Fall back to searching the name alone to try to match the user
+		players, err = c.retrievePlayers(ctx, bt.Name)
 
 		if err != nil {
 			return nil, err
 		}
 
-		for _, p := range players {
-			if strings.EqualFold(tag, p.BattleTag) {
-				player = &p
-				break
-			}
-		}
+		matches = matchPlayers(bt, players)
+	}
 
-		if player == nil {
-			return nil, ErrPlayerNotFound
-		}
-	} else {
-		player = &players[0]
+	switch len(matches) {
+	case 0:
+		return nil, ErrPlayerNotFound
+	case 1:
+		return &matches[0], nil
 	}
 
-	if !player.IsPublic {
-		ps.Private = true
-		return &ps, nil
+	// A name without a discriminator can't tell the accounts apart
+	return nil, &AmbiguousPlayerError{Name: bt.Name, Candidates: matches}
+}
+
+// matchPlayers returns the players belonging to the passed battletag
+func matchPlayers(bt BattleTag, players []Player) []Player {
+	var matches []Player
+
+	for _, p := range players {
+		if bt.Matches(p.BattleTag) {
+			matches = append(matches, p)
+		}
 	}
 
+	return matches
+}
+
+// fetchProfile retrieves the career page of the passed player. The caller is
+// responsible for closing the response body
+func (c *Client) fetchProfile(ctx context.Context, player *Player) (*http.Response, error) {
 	// Create the profile url for scraping
-	profileUrl := baseURL + "/" + player.URL + "/"
+	profileUrl := c.careerURL + "/" + player.URL + "/"
 
 	// Perform the stats request and decode the response
-	res, err := http.Get(profileUrl)
+	res, err := c.get(ctx, profileUrl)
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to retrieve profile")
+		return nil, upstreamErr(profileUrl, err)
 	}
-	defer res.Body.Close()
 
+	// Checks the response is a career page before handing it to the parser
+	if err := checkResponse(res, profileUrl, mimeHTML, true); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// pageErr sets the URL and status of the career page a parse error was found
+// in on the upstream errors the parser returns without them
+func pageErr(res *http.Response, err error) error {
+	var uerr *UpstreamError
+	if errors.As(err, &uerr) && uerr.URL == "" {
+		uerr.URL = res.Request.URL.String()
+		uerr.StatusCode = res.StatusCode
+	}
+	return err
+}
+
+// ParseProfile builds a PlayerStats for the passed platform from a career page
+// HTML document, such as one previously saved from the Overwatch site. It
+// performs no network requests
+func ParseProfile(r io.Reader, platformKey string) (*PlayerStats, error) {
+	return parseProfile(r, platformKey)
+}
+
+// ParseAllProfiles builds the stats of every platform present on a career page
+// HTML document. It performs no network requests
+func ParseAllProfiles(r io.Reader) (*ProfileStats, error) {
+	return parseAllProfiles(r)
+}
+
+// parser scrapes a career page, recording a warning for anything it fails to
+// extract rather than giving up on the whole profile
+type parser struct {
+	warnings []ParseWarning
+	drift    []Drift
+	seen     map[Drift]bool
+}
+
+// reportDrift records the passed difference from the expected page layout once
+// per parse, no matter how many heroes or modes it shows up in
+func (p *parser) reportDrift(kind DriftKind, selector, name string) {
+	d := Drift{Kind: kind, Selector: selector, Name: name}
+
+	if p.seen == nil {
+		p.seen = make(map[Drift]bool)
+	}
+
+	if p.seen[d] {
+		return
+	}
+
+	p.seen[d] = true
+	p.drift = append(p.drift, d)
+}
+
+// expect reports a missing selector drift if the passed selection is empty
+func (p *parser) expect(sel *goquery.Selection, selector string) bool {
+	if sel.Length() == 0 {
+		p.reportDrift(DriftMissingSelector, selector, "")
+		return false
+	}
+	return true
+}
+
+// warn records a ParseWarning for the passed selector and field
+func (p *parser) warn(selector, field, reason string, args ...interface{}) {
+	p.warnings = append(p.warnings, ParseWarning{
+		Selector: selector,
+		Field:    field,
+		Reason:   fmt.Sprintf(reason, args...),
+	})
+}
+
+// atoi converts the passed stat value to an int, warning if it isn't one
+func (p *parser) atoi(selector, field, val string) int {
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		p.warn(selector, field, "invalid integer %q", val)
+	}
+	return i
+}
+
+// parseFloat converts the passed stat value to a float64, warning if it isn't one
+func (p *parser) parseFloat(selector, field, val string) float64 {
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		p.warn(selector, field, "invalid number %q", val)
+	}
+	return f
+}
+
+// duration converts the passed stat value to a time.Duration, warning if it
+// isn't one
+func (p *parser) duration(selector, field, val string) time.Duration {
+	d, ok := parseDuration(val)
+	if !ok {
+		p.warn(selector, field, "invalid duration %q", val)
+	}
+	return d
+}
+
+// percentage converts the passed stat value to the number of a percentage,
+// warning if it isn't one. The percent sign is optional
+func (p *parser) percentage(selector, field, val string) float64 {
+	if f, ok := parsePercentage(val); ok {
+		return f
+	}
+	return p.parseFloat(selector, field, strings.TrimSpace(val))
+}
+
+// fork returns a copy of the parser so page wide warnings and drift can be
+// shared by the stats of every platform
+func (p *parser) fork() *parser {
+	fp := &parser{
+		warnings: append([]ParseWarning(nil), p.warnings...),
+		drift:    append([]Drift(nil), p.drift...),
+		seen:     make(map[Drift]bool, len(p.seen)),
+	}
+
+	for d := range p.seen {
+		fp.seen[d] = true
+	}
+
+	return fp
+}
+
+// parseProfile parses the passed career page and scrapes the stats of the
+// passed platform out of it. Anything that can't be extracted is reported in
+// the returned stats warnings alongside whatever could be
+func parseProfile(r io.Reader, platformKey string) (*PlayerStats, error) {
 	// Parses the stats request into a goquery document
-	pd, err := goquery.NewDocumentFromReader(res.Body)
+	pd, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to create goquery document")
 	}
 
-	// Checks if profile not found, site still returns 200 in this case
-	if res.StatusCode == http.StatusNotFound {
-		return nil, ErrPlayerNotFound
+	if err := careerPageErr(pd); err != nil {
+		return nil, err
+	}
+
+	var p parser
+
+	p.expect(pd.Find(".Profile-player--filters"), ".Profile-player--filters")
+
+	if platformKey != PlatformPC && platformKey != PlatformConsole {
+		return nil, ErrInvalidPlatform
+	}
+
+	platform, exists := p.parsePlatforms(pd)[platformFilterID(platformKey)]
+
+	if !exists {
+		return nil, ErrPlatformNotFound
+	}
+
+	return p.parsePlayerStats(pd, platform), nil
+}
+
+// careerPageErr returns why the passed page isn't a career page, nil when it
+// is. The page served in place of the career page of a missing player is
+// ErrPlayerNotFound, any other page without the platform filters or the
+// masthead is a markup change rather than a missing player
+func careerPageErr(pd *goquery.Document) error {
+	if pd.Find(".Profile-player--filters").Length() > 0 || pd.Find(".Profile-masthead").Length() > 0 {
+		return nil
+	}
+
+	notFound := pd.Find("title, h1").FilterFunction(func(i int, sel *goquery.Selection) bool {
+		return notFoundRegexp.MatchString(sel.Text())
+	})
+	if notFound.Length() > 0 {
+		return ErrPlayerNotFound
+	}
+
+	return &UpstreamError{
+		Kind: ErrMarkupChanged,
+		Err:  errors.New("Page has neither .Profile-player--filters nor .Profile-masthead"),
 	}
+}
+
+// parseAllProfiles parses the passed career page and scrapes the stats of
+// every platform present on it
+func parseAllProfiles(r io.Reader) (*ProfileStats, error) {
+	// Parses the stats request into a goquery document
+	pd, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create goquery document")
+	}
+
+	if err := careerPageErr(pd); err != nil {
+		return nil, err
+	}
+
+	var p parser
+
+	p.expect(pd.Find(".Profile-player--filters"), ".Profile-player--filters")
+
+	platforms := p.parsePlatforms(pd)
+
+	profile := &ProfileStats{
+		Platforms: make([]string, 0, len(platforms)),
+		Stats:     make(map[string]*PlayerStats, len(platforms)),
+	}
+
+	for id, platform := range platforms {
+		key := platformKey(id)
+
+		profile.Platforms = append(profile.Platforms, key)
+		profile.Stats[key] = p.fork().parsePlayerStats(pd, platform)
+	}
+
+	sort.Strings(profile.Platforms)
+
+	return profile, nil
+}
+
+// parsePlayerStats scrapes the stats of a single platform out of the page
+func (p *parser) parsePlayerStats(pd *goquery.Document, platform Platform) (ps *PlayerStats) {
+	ps = new(PlayerStats)
+
+	// Markup we don't understand must never take the caller down with it, so
+	// anything that still panics is reported as a warning on the partial stats
+	defer func() {
+		if r := recover(); r != nil {
+			p.warn("", "", "parser panic: %v", r)
+		}
+		ps.Warnings = p.warnings
+		ps.Drift = p.drift
+	}()
 
 	ps.Name = pd.Find(".Profile-player--name").Text()
 
+	if ps.Name == "" {
+		p.warn(".Profile-player--name", "name", "not found")
+	}
+
+	masthead := pd.Find(".Profile-masthead").First()
+	p.expect(masthead, ".Profile-masthead")
+
+	// Scrapes all stats for the passed user and sets struct member data
+	p.parseGeneralInfo(platform, masthead, ps)
+
+	p.parseDetailedStats(platform, ".quickPlay-view", &ps.QuickPlayStats.StatsCollection)
+	p.parseDetailedStats(platform, ".competitive-view", &ps.CompetitiveStats.StatsCollection)
+
+	competitiveSeason, _ := pd.Find("[data-latestherostatrankseasonow2]").Attr("data-latestherostatrankseasonow2")
+
+	if competitiveSeason != "" {
+		competitiveSeason := p.atoi("[data-latestherostatrankseasonow2]", "competitiveStats.season", competitiveSeason)
+
+		ps.CompetitiveStats.Season = &competitiveSeason
+	}
+
+	p.addGameStats(ps, &ps.QuickPlayStats.StatsCollection)
+	p.addGameStats(ps, &ps.CompetitiveStats.StatsCollection)
+
+	return ps
+}
+
+// platformFilterID maps a public platform key to the id used by the page filters
+func platformFilterID(platformKey string) string {
+	switch platformKey {
+	case PlatformPC:
+		return "mouseKeyboard"
+	case PlatformConsole:
+		return "controller"
+	}
+	return platformKey
+}
+
+// platformKey maps a page filter id to its public platform key
+func platformKey(filterID string) string {
+	switch filterID {
+	case "mouseKeyboard":
+		return PlatformPC
+	case "controller":
+		return PlatformConsole
+	}
+	return filterID
+}
+
+// parsePlatforms finds every platform that has a profile view on the page,
+// keyed by the page filter id
+func (p *parser) parsePlatforms(pd *goquery.Document) map[string]Platform {
 	platforms := make(map[string]Platform)
 
 	pd.Find(".Profile-player--filters .Profile-player--filter").Each(func(i int, sel *goquery.Selection) {
 		id, _ := sel.Attr("id")
 
-		id = filterRegexp.FindStringSubmatch(id)[1]
+		filterInfo := filterRegexp.FindStringSubmatch(id)
+
+		if filterInfo == nil {
+			p.warn(".Profile-player--filter", "platforms", "unrecognized filter id %q", id)
+			return
+		}
+
+		id = filterInfo[1]
 
 		viewID := "." + id + "-view"
 
@@ -141,69 +496,73 @@ func Stats(platformKey, tag string) (*PlayerStats, error) {
 		}
 	})
 
-	platform, exists := platforms[platformKey]
-
-	if !exists {
-		return nil, ErrInvalidPlatform
-	}
-
-	// Scrapes all stats for the passed user and sets struct member data
-	parseGeneralInfo(platform, pd.Find(".Profile-masthead").First(), &ps)
-
-	parseDetailedStats(platform, ".quickPlay-view", &ps.QuickPlayStats.StatsCollection)
-	parseDetailedStats(platform, ".competitive-view", &ps.CompetitiveStats.StatsCollection)
-
-	competitiveSeason, _ := pd.Find("[data-latestherostatrankseasonow2]").Attr("data-latestherostatrankseasonow2")
+	return platforms
+}
 
-	if competitiveSeason != "" {
-		competitiveSeason, _ := strconv.Atoi(competitiveSeason)
+// addGameStats adds the all heroes game totals of the passed collection to the
+// players overall game counts
+func (p *parser) addGameStats(ps *PlayerStats, statsCollection *StatsCollection) {
+	heroStats, ok := statsCollection.CareerStats["allHeroes"]
 
-		ps.CompetitiveStats.Season = &competitiveSeason
+	if !ok {
+		return
 	}
 
-	addGameStats(&ps, &ps.QuickPlayStats.StatsCollection)
-	addGameStats(&ps, &ps.CompetitiveStats.StatsCollection)
+	for key, total := range map[string]*int{
+		"gamesPlayed": &ps.GamesPlayed,
+		"gamesWon":    &ps.GamesWon,
+		"gamesLost":   &ps.GamesLost,
+	} {
+		val, ok := heroStats.Game[key]
 
-	return &ps, nil
-}
-
-func addGameStats(ps *PlayerStats, statsCollection *StatsCollection) {
-	if heroStats, ok := statsCollection.CareerStats["allHeroes"]; ok {
-		if gamesPlayed, ok := heroStats.Game["gamesPlayed"]; ok {
-			ps.GamesPlayed += gamesPlayed.(int)
-		}
-
-		if gamesWon, ok := heroStats.Game["gamesWon"]; ok {
-			ps.GamesWon += gamesWon.(int)
+		if !ok {
+			continue
 		}
 
-		if gamesLost, ok := heroStats.Game["gamesLost"]; ok {
-			ps.GamesLost += gamesLost.(int)
+		if i, ok := val.(int); ok {
+			*total += i
+		} else {
+			p.warn(".stats-container .stat-item", "careerStats.allHeroes.game."+key, "invalid integer %v", val)
 		}
 	}
 }
 
-func retrievePlayers(tag string) ([]Player, error) {
+func (c *Client) retrievePlayers(ctx context.Context, tag string) ([]Player, error) {
 	// Perform api request
 	var platforms []Player
 
-	apires, err := http.Get(apiURL + url.PathEscape(tag))
+	searchURL := c.searchURL + url.PathEscape(tag)
+
+	apires, err := c.get(ctx, searchURL)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to perform platform API request")
+		return nil, upstreamErr(searchURL, err)
 	}
 
+	// Checks the response is a list of players before decoding it
+	if err := checkResponse(apires, searchURL, mimeJSON, false); err != nil {
+		return nil, err
+	}
 	defer apires.Body.Close()
 
 	// Decode received JSON
 	if err := json.NewDecoder(apires.Body).Decode(&platforms); err != nil {
-		return nil, errors.Wrap(err, "Failed to decode platform API response")
+		return nil, &UpstreamError{
+			URL:        searchURL,
+			StatusCode: apires.StatusCode,
+			Kind:       ErrMarkupChanged,
+			Err:        errors.Wrap(err, "Failed to decode platform API response"),
+		}
 	}
 
 	return platforms, nil
 }
 
 var (
+	// notFoundRegexp matches the title or heading of the page served in place
+	// of the career page of a player that doesn't exist
+	notFoundRegexp = regexp.MustCompile(`(?i)\b(profile|page) not found\b`)
+
 	endorsementRegexp = regexp.MustCompile("/(\\d+)-([a-z0-9]+)\\.svg")
 	rankRegexp        = regexp.MustCompile("([a-zA-Z0-9]+)Tier-([a-z\\d]+)\\.(svg|png)")
 	divisionRegexp    = regexp.MustCompile("TierDivision_(\\d+)-([a-z\\d]+)\\.(svg|png)")
@@ -212,11 +571,25 @@ var (
 
 // populateGeneralInfo extracts the users general info and returns it in a
 // PlayerStats struct
-func parseGeneralInfo(platform Platform, s *goquery.Selection, ps *PlayerStats) {
+func (p *parser) parseGeneralInfo(platform Platform, s *goquery.Selection, ps *PlayerStats) {
+	var found bool
+
 	// Populates all general player information
-	ps.Icon, _ = s.Find(".Profile-player--portrait").Attr("src")
-	ps.EndorsementIcon, _ = s.Find(".Profile-playerSummary--endorsement").Attr("src")
-	ps.Endorsement, _ = strconv.Atoi(endorsementRegexp.FindStringSubmatch(ps.EndorsementIcon)[1])
+	if ps.Icon, found = s.Find(".Profile-player--portrait").Attr("src"); !found {
+		p.warn(".Profile-player--portrait", "icon", "not found")
+		p.reportDrift(DriftMissingSelector, ".Profile-player--portrait", "")
+	}
+
+	endorsement := s.Find(".Profile-playerSummary--endorsement")
+	p.expect(endorsement, ".Profile-playerSummary--endorsement")
+
+	ps.EndorsementIcon, _ = endorsement.Attr("src")
+
+	if endorsementInfo := endorsementRegexp.FindStringSubmatch(ps.EndorsementIcon); endorsementInfo != nil {
+		ps.Endorsement, _ = strconv.Atoi(endorsementInfo[1])
+	} else {
+		p.warn(".Profile-playerSummary--endorsement", "endorsement", "unrecognized endorsement icon %q", ps.EndorsementIcon)
+	}
 
 	// Parse Endorsement Icon path (/svg?path=)
 	if strings.Index(ps.EndorsementIcon, "/svg") == 0 {
@@ -229,7 +602,6 @@ func parseGeneralInfo(platform Platform, s *goquery.Selection, ps *PlayerStats)
 
 	// Ratings
 	// Note that .is-active is the default platform
-	// Note that this should have error handling! This is bad code!
 	platform.RankWrapper.Find("div.Profile-playerSummary--roleWrapper").Each(func(i int, sel *goquery.Selection) {
 		// Rank selections.
 		roleIcon, found := sel.Find("img").Attr("src")
@@ -241,7 +613,13 @@ func parseGeneralInfo(platform Platform, s *goquery.Selection, ps *PlayerStats)
 		// Format is /(offense|support|...)-HEX.(png|svg)
 		// svg is in another tag, png is in the img tag
 		role := path.Base(roleIcon)
-		role = role[0:strings.Index(role, "-")]
+
+		if i := strings.Index(role, "-"); i > 0 {
+			role = role[0:i]
+		} else {
+			p.warn("div.Profile-playerSummary--roleWrapper img", "ratings.role", "unrecognized role icon %q", roleIcon)
+			return
+		}
 
 		rankIcons := sel.Find("img.Profile-playerSummary--rank")
 
@@ -261,18 +639,27 @@ func parseGeneralInfo(platform Platform, s *goquery.Selection, ps *PlayerStats)
 			return
 		}
 
-		rankInfo := rankRegexp.FindStringSubmatch(rankIcon)
-		divisionInfo := divisionRegexp.FindStringSubmatch(divisionIcon)
-		tier, _ := strconv.Atoi(divisionInfo[1])
-
-		ps.Ratings = append(ps.Ratings, Rating{
-			Group:        rankInfo[1],
-			Tier:         tier,
+		rating := Rating{
 			Role:         role,
 			RoleIcon:     roleIcon,
 			RankIcon:     rankIcon,
 			DivisionIcon: divisionIcon,
-		})
+		}
+
+		// Unrecognized icons still leave us with the role and its icons
+		if rankInfo := rankRegexp.FindStringSubmatch(rankIcon); rankInfo != nil {
+			rating.Group = rankInfo[1]
+		} else {
+			p.warn("img.Profile-playerSummary--rank", "ratings."+role+".group", "unrecognized rank icon %q", rankIcon)
+		}
+
+		if divisionInfo := divisionRegexp.FindStringSubmatch(divisionIcon); divisionInfo != nil {
+			rating.Tier, _ = strconv.Atoi(divisionInfo[1])
+		} else {
+			p.warn("img.Profile-playerSummary--rank", "ratings."+role+".tier", "unrecognized division icon %q", divisionIcon)
+		}
+
+		ps.Ratings = append(ps.Ratings, rating)
 	})
 }
 
@@ -307,13 +694,13 @@ func getAttributeValue(attrName string, n *html.Node) (val string, exists bool)
 }
 
 // parseDetailedStats populates the passed stats collection with detailed statistics
-func parseDetailedStats(platform Platform, playMode string, sc *StatsCollection) {
-	sc.TopHeroes = parseHeroStats(platform.ProfileView.Find(".Profile-heroSummary--view" + playMode))
-	sc.CareerStats = parseCareerStats(platform.ProfileView.Find(".stats" + playMode))
+func (p *parser) parseDetailedStats(platform Platform, playMode string, sc *StatsCollection) {
+	sc.TopHeroes = p.parseHeroStats(platform.ProfileView.Find(".Profile-heroSummary--view"+playMode), playMode)
+	sc.CareerStats = p.parseCareerStats(platform.ProfileView.Find(".stats" + playMode))
 }
 
 // parseHeroStats : Parses stats for each individual hero and returns a map
-func parseHeroStats(heroStatsSelector *goquery.Selection) map[string]*TopHeroStats {
+func (p *parser) parseHeroStats(heroStatsSelector *goquery.Selection, playMode string) map[string]*TopHeroStats {
 	bhsMap := make(map[string]*TopHeroStats)
 	categoryMap := make(map[string]string)
 
@@ -324,9 +711,20 @@ func parseHeroStats(heroStatsSelector *goquery.Selection) map[string]*TopHeroSta
 		categoryMap[optionVal] = cleanJSONKey(optionName)
 	})
 
+	// A mode the player never played has no view at all, but one that is
+	// present should always have progress bars to parse
+	if heroStatsSelector.Length() > 0 && heroStatsSelector.Find("div.Profile-progressBars").Length() == 0 {
+		p.reportDrift(DriftEmptySection, ".Profile-heroSummary--view"+playMode, "")
+	}
+
 	heroStatsSelector.Find("div.Profile-progressBars").Each(func(i int, heroGroupSel *goquery.Selection) {
-		categoryID, _ := heroGroupSel.Attr("data-category-id")
-		categoryID = categoryMap[categoryID]
+		rawCategoryID, _ := heroGroupSel.Attr("data-category-id")
+		categoryID, known := categoryMap[rawCategoryID]
+
+		if !known {
+			p.reportDrift(DriftUnknownTopHeroMetric, "div.Profile-progressBars[data-category-id]", rawCategoryID)
+			return
+		}
 
 		heroGroupSel.Find(".Profile-progressBar").Each(func(i2 int, statSel *goquery.Selection) {
 			heroName := cleanJSONKey(statSel.Find(".Profile-progressBar-title").Text())
@@ -337,22 +735,30 @@ func parseHeroStats(heroStatsSelector *goquery.Selection) map[string]*TopHeroSta
 				bhsMap[heroName] = new(TopHeroStats)
 			}
 
+			selector := ".Profile-heroSummary--view" + playMode + " .Profile-progressBar-description"
+			field := "topHeroes." + heroName + "." + categoryID
+
 			// Sets hero stats based on stat category type
 			switch categoryID {
 			case "timePlayed":
 				bhsMap[heroName].TimePlayed = statVal
+				bhsMap[heroName].TimePlayedSeconds = int64(p.duration(selector, field, statVal).Seconds())
 			case "gamesWon":
-				bhsMap[heroName].GamesWon, _ = strconv.Atoi(statVal)
+				bhsMap[heroName].GamesWon = p.atoi(selector, field, statVal)
 			case "weaponAccuracy":
-				bhsMap[heroName].WeaponAccuracy, _ = strconv.Atoi(strings.Replace(statVal, "%", "", -1))
+				bhsMap[heroName].WeaponAccuracyPercent = p.percentage(selector, field, statVal)
+				bhsMap[heroName].WeaponAccuracy = int(math.Round(bhsMap[heroName].WeaponAccuracyPercent))
 			case "criticalHitAccuracy":
-				bhsMap[heroName].CriticalHitAccuracy, _ = strconv.Atoi(strings.Replace(statVal, "%", "", -1))
+				bhsMap[heroName].CriticalHitAccuracyPercent = p.percentage(selector, field, statVal)
+				bhsMap[heroName].CriticalHitAccuracy = int(math.Round(bhsMap[heroName].CriticalHitAccuracyPercent))
 			case "eliminationsPerLife":
-				bhsMap[heroName].EliminationsPerLife, _ = strconv.ParseFloat(statVal, 64)
+				bhsMap[heroName].EliminationsPerLife = p.parseFloat(selector, field, statVal)
 			case "multikillBest":
-				bhsMap[heroName].MultiKillBest, _ = strconv.Atoi(statVal)
+				bhsMap[heroName].MultiKillBest = p.atoi(selector, field, statVal)
 			case "objectiveKills":
-				bhsMap[heroName].ObjectiveKills, _ = strconv.ParseFloat(statVal, 64)
+				bhsMap[heroName].ObjectiveKills = p.parseFloat(selector, field, statVal)
+			default:
+				p.reportDrift(DriftUnknownTopHeroMetric, ".Profile-heroSummary--view .Profile-dropdown option", categoryID)
 			}
 		})
 	})
@@ -360,7 +766,7 @@ func parseHeroStats(heroStatsSelector *goquery.Selection) map[string]*TopHeroSta
 }
 
 // parseCareerStats
-func parseCareerStats(careerStatsSelector *goquery.Selection) map[string]*CareerStats {
+func (p *parser) parseCareerStats(careerStatsSelector *goquery.Selection) map[string]*CareerStats {
 	csMap := make(map[string]*CareerStats)
 	heroMap := make(map[string]string)
 
@@ -370,6 +776,10 @@ func parseCareerStats(careerStatsSelector *goquery.Selection) map[string]*Career
 		heroMap[heroVal] = heroSel.Text()
 	})
 
+	if careerStatsSelector.Length() > 0 && careerStatsSelector.Find(".stats-container").Length() == 0 {
+		p.reportDrift(DriftEmptySection, ".stats .stats-container", "")
+	}
+
 	// Iterates over every hero div
 	careerStatsSelector.Find(".stats-container").Each(func(i int, heroStatsSel *goquery.Selection) {
 		classAttributes, _ := heroStatsSel.Attr("class")
@@ -400,8 +810,8 @@ func parseCareerStats(careerStatsSelector *goquery.Selection) map[string]*Career
 			// Iterates over stat row
 			statBoxSel.Find(".stat-item").Each(func(i3 int, statSel *goquery.Selection) {
 				statKey := transformKey(cleanJSONKey(statSel.Find(".name").Text()))
-				statVal := strings.Replace(statSel.Find(".value").Text(), ",", "", -1) // Removes commas from 1k+ values
-				statVal = strings.TrimSpace(statVal)
+				statDisplay := strings.TrimSpace(statSel.Find(".value").Text())
+				statVal := strings.Replace(statDisplay, ",", "", -1) // Removes commas from 1k+ values
 
 				// Creates stat map if it doesn't exist
 				if csMap[currentHero] == nil {
@@ -450,7 +860,12 @@ func parseCareerStats(careerStatsSelector *goquery.Selection) map[string]*Career
 						csMap[currentHero].MatchAwards = make(map[string]interface{})
 					}
 					csMap[currentHero].MatchAwards[statKey] = parseType(statVal)
+				default:
+					p.reportDrift(DriftUnknownCategory, "div.category .header p", statType)
+					return
 				}
+
+				csMap[currentHero].setValue(statType, statKey, parseStatValue(statDisplay))
 			})
 		})
 	})
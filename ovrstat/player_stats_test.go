@@ -1,11 +1,17 @@
 package ovrstat
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
+var update = flag.Bool("update", false, "update golden files")
+
 func TestPlayerStats(t *testing.T) {
 	if os.Getenv("TEST_PLATFORM") == "" {
 		t.Skip("Skipping test due to missing platform")
@@ -29,3 +35,177 @@ func TestPlayerStats(t *testing.T) {
 
 	t.Log(string(b))
 }
+
+func TestParseProfile(t *testing.T) {
+	for _, platform := range []string{PlatformPC, PlatformConsole} {
+		t.Run(platform, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", "profile.html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			stats, err := ParseProfile(f, platform)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := json.MarshalIndent(stats, "", "\t")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", "profile_"+platform+".golden.json")
+
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("parsed profile does not match %s, run with -update to regenerate\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestParseProfileInvalidPlatform(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := ParseProfile(f, "switch"); err != ErrInvalidPlatform {
+		t.Fatalf("expected ErrInvalidPlatform, got %v", err)
+	}
+}
+
+func TestParseProfilePlatformNotFound(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a player who only ever played on pc
+	page := strings.Replace(string(b), `<div class="Profile-player--filter" id="controllerFilter">Console</div>`, "", 1)
+
+	if _, err := ParseProfile(strings.NewReader(page), PlatformConsole); err != ErrPlatformNotFound {
+		t.Fatalf("expected ErrPlatformNotFound, got %v", err)
+	}
+}
+
+func TestParseProfileWarnings(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate markup changes that used to panic the parser
+	page := strings.NewReplacer(
+		"endorsement/3-8ccb5f0aef.svg", "endorsement/three.svg",
+		"GoldTier-6ec2d1fc3d.png", "gold.png",
+		"TierDivision_3-af1a5e6f7c.png", "division.png",
+		"role/support-f64c2b3ec4.svg", "role/support.svg",
+	).Replace(string(b))
+
+	stats, err := ParseProfile(strings.NewReader(page), PlatformPC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := make(map[string]bool)
+	for _, w := range stats.Warnings {
+		fields[w.Field] = true
+	}
+
+	for _, field := range []string{"endorsement", "ratings.tank.group", "ratings.tank.tier", "ratings.role"} {
+		if !fields[field] {
+			t.Errorf("expected a warning for %s, got %+v", field, stats.Warnings)
+		}
+	}
+
+	// Whatever could still be extracted is returned
+	if stats.Name != "Viz" || len(stats.Ratings) != 1 || stats.GamesPlayed == 0 {
+		t.Errorf("expected partial stats, got %+v", stats)
+	}
+}
+
+func TestParseProfileDrift(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := ParseProfile(bytes.NewReader(b), PlatformPC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats.Drift) != 0 {
+		t.Fatalf("expected no drift for the fixture, got %+v", stats.Drift)
+	}
+
+	// Simulate Blizzard renaming a category and adding a top heroes metric
+	page := strings.NewReplacer(
+		"<p>Match Awards</p>", "<p>Match Honors</p>",
+		"Critical Hit Accuracy", "Critical Hit Rate",
+	).Replace(string(b))
+
+	stats, err = ParseProfile(strings.NewReader(page), PlatformPC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[Drift]bool{
+		{Kind: DriftUnknownCategory, Selector: "div.category .header p", Name: "matchHonors"}:                                       true,
+		{Kind: DriftUnknownTopHeroMetric, Selector: ".Profile-heroSummary--view .Profile-dropdown option", Name: "criticalHitRate"}: true,
+	}
+
+	if len(stats.Drift) != len(want) {
+		t.Fatalf("expected %d drift entries, got %+v", len(want), stats.Drift)
+	}
+
+	for _, d := range stats.Drift {
+		if !want[d] {
+			t.Errorf("unexpected drift %+v", d)
+		}
+	}
+}
+
+func TestParseAllProfiles(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := ParseAllProfiles(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(profile.Platforms) != 2 || profile.Platforms[0] != PlatformConsole || profile.Platforms[1] != PlatformPC {
+		t.Fatalf("unexpected platforms %v", profile.Platforms)
+	}
+
+	// Every platform matches what a single platform parse returns
+	for _, platform := range profile.Platforms {
+		stats, err := ParseProfile(bytes.NewReader(b), platform)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, _ := json.Marshal(stats)
+		got, _ := json.Marshal(profile.Stats[platform])
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s stats differ from ParseProfile", platform)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package ovrstat
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+const (
+	// mimeJSON is the content type of search API responses
+	mimeJSON = "application/json"
+
+	// mimeHTML is the content type of career pages
+	mimeHTML = "text/html"
+
+	// maxErrorBody bounds how much of an unexpected response is read to
+	// classify it
+	maxErrorBody = 64 << 10
+)
+
+var (
+	// maintenanceRegexp matches the text of the Overwatch site maintenance
+	// page, served in place of an error response
+	maintenanceRegexp = regexp.MustCompile(`(?i)maintenance`)
+
+	// maintenanceTitleRegexp matches the title of the maintenance page. Unlike
+	// maintenanceRegexp it can't match a career page, which may mention
+	// maintenance anywhere in its text
+	maintenanceTitleRegexp = regexp.MustCompile(`(?i)<title>[^<]*maintenance[^<]*</title>`)
+)
+
+// checkResponse validates the status and content type of a response from the
+// passed url before it's handed to a decoder expecting mediaType. Unexpected
+// responses are closed and classified as rate limiting, maintenance, the
+// player not being found (when notFound is set) or another upstream error
+func checkResponse(res *http.Response, url, mediaType string, notFound bool) error {
+	ct, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+
+	if res.StatusCode == http.StatusOK && ct == mediaType {
+		if ct != mimeHTML {
+			return nil
+		}
+
+		// The maintenance page is also served with a 200 in place of a career
+		// page, so the head of the page is checked before it's handed over
+		head, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		if !maintenanceTitleRegexp.Match(head) {
+			res.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(head), res.Body), Closer: res.Body}
+			return nil
+		}
+		res.Body.Close()
+
+		return &UpstreamError{
+			URL:        url,
+			StatusCode: res.StatusCode,
+			Kind:       ErrUpstreamUnavailable,
+			Err:        ErrUpstreamMaintenance,
+		}
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+	uerr := &UpstreamError{URL: url, StatusCode: res.StatusCode}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound && notFound:
+		return ErrPlayerNotFound
+	case res.StatusCode == http.StatusTooManyRequests:
+		uerr.Kind = ErrUpstreamRateLimited
+		uerr.RetryAfter, _ = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+	case ct == mimeHTML && maintenanceRegexp.Match(body):
+		// Maintenance pages are served with a 503 as well as a 200 in place of
+		// a search response
+		uerr.Kind = ErrUpstreamUnavailable
+		uerr.Err = ErrUpstreamMaintenance
+		uerr.RetryAfter, _ = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+	case res.StatusCode == http.StatusOK:
+		uerr.Kind = ErrMarkupChanged
+		uerr.Err = &contentTypeError{got: ct, want: mediaType}
+	default:
+		uerr.Kind = ErrUpstreamUnavailable
+		uerr.RetryAfter, _ = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+	}
+
+	return uerr
+}
+
+// contentTypeError is the underlying error of a response served with an
+// unexpected content type
+type contentTypeError struct {
+	got, want string
+}
+
+// Error implements the error interface
+func (e *contentTypeError) Error() string {
+	got := e.got
+	if got == "" {
+		got = "none"
+	}
+	return "Unexpected content type " + got + ", expected " + e.want
+}
+
+// peekedBody is a response body whose head has already been read, reading it
+// again before the rest of the body
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
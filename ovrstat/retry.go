@@ -0,0 +1,95 @@
+package ovrstat
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryable reports whether a response status is worth retrying the request
+// for: rate limiting and server errors are usually transient
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses the value of a Retry-After header, in either its
+// seconds or HTTP date form, into the time to wait from now
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// backoff returns how long to wait before the passed retry attempt (starting
+// at 0), a random duration up to an exponentially growing cap ("full jitter")
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.retryMaxDelay
+	if attempt < 30 && c.retryBaseDelay<<attempt < d {
+		d = c.retryBaseDelay << attempt
+	}
+
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
+
+// getWithRetries performs a GET request on the passed url, retrying it on
+// transport errors and retryable statuses with a jittered exponential backoff.
+// A Retry-After sent by the Overwatch site is waited for instead, unless it's
+// longer than the maximum delay in which case the response is returned as is
+func (c *Client) getWithRetries(ctx context.Context, url string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx, url); err != nil {
+			return nil, err
+		}
+
+		res, err := c.do(ctx, url)
+		if attempt >= c.retries || ctx.Err() != nil {
+			return res, err
+		}
+
+		delay := c.backoff(attempt)
+
+		if err == nil {
+			if !retryable(res.StatusCode) {
+				return res, nil
+			}
+
+			if d, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+				if d > c.retryMaxDelay {
+					return res, nil
+				}
+				delay = d
+			}
+
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package ovrstat
+
+import "context"
+
+// Search returns every account matching the passed name or battletag using
+// the DefaultClient
+func Search(name string) ([]Player, error) {
+	return DefaultClient.SearchContext(context.Background(), name)
+}
+
+// SearchContext returns every account matching the passed name or battletag
+// using the DefaultClient, aborting the search when the passed context is
+// cancelled
+func SearchContext(ctx context.Context, name string) ([]Player, error) {
+	return DefaultClient.SearchContext(ctx, name)
+}
+
+// Search returns every account matching the passed name or battletag
+func (c *Client) Search(name string) ([]Player, error) {
+	return c.SearchContext(context.Background(), name)
+}
+
+// SearchContext returns every account matching the passed name or battletag,
+// in the order the search API returns them
+func (c *Client) SearchContext(ctx context.Context, name string) ([]Player, error) {
+	players, err := c.retrievePlayers(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if players == nil {
+		players = []Player{}
+	}
+
+	return players, nil
+}
@@ -0,0 +1,5 @@
+// Package ovrstatpb holds the protobuf messages and gRPC service of the ovrstat
+// API, generated from ovrstat.proto
+package ovrstatpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ovrstat.proto
@@ -0,0 +1,1639 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: ovrstat.proto
+
+package ovrstatpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StatKind identifies what a career stat value measures
+type StatKind int32
+
+const (
+	StatKind_STAT_KIND_UNSPECIFIED StatKind = 0
+	StatKind_STAT_KIND_COUNT       StatKind = 1
+	StatKind_STAT_KIND_DURATION    StatKind = 2
+	StatKind_STAT_KIND_PERCENTAGE  StatKind = 3
+	StatKind_STAT_KIND_RATIO       StatKind = 4
+	StatKind_STAT_KIND_TEXT        StatKind = 5
+)
+
+// Enum value maps for StatKind.
+var (
+	StatKind_name = map[int32]string{
+		0: "STAT_KIND_UNSPECIFIED",
+		1: "STAT_KIND_COUNT",
+		2: "STAT_KIND_DURATION",
+		3: "STAT_KIND_PERCENTAGE",
+		4: "STAT_KIND_RATIO",
+		5: "STAT_KIND_TEXT",
+	}
+	StatKind_value = map[string]int32{
+		"STAT_KIND_UNSPECIFIED": 0,
+		"STAT_KIND_COUNT":       1,
+		"STAT_KIND_DURATION":    2,
+		"STAT_KIND_PERCENTAGE":  3,
+		"STAT_KIND_RATIO":       4,
+		"STAT_KIND_TEXT":        5,
+	}
+)
+
+func (x StatKind) Enum() *StatKind {
+	p := new(StatKind)
+	*p = x
+	return p
+}
+
+func (x StatKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_ovrstat_proto_enumTypes[0].Descriptor()
+}
+
+func (StatKind) Type() protoreflect.EnumType {
+	return &file_ovrstat_proto_enumTypes[0]
+}
+
+func (x StatKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatKind.Descriptor instead.
+func (StatKind) EnumDescriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{0}
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Platform is "pc" or "console"
+	Platform string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	// Tag is the battletag of the player, such as "Viz-1213"
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetStatsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *GetStatsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type SearchPlayersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SearchPlayersRequest) Reset() {
+	*x = SearchPlayersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPlayersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPlayersRequest) ProtoMessage() {}
+
+func (x *SearchPlayersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPlayersRequest.ProtoReflect.Descriptor instead.
+func (*SearchPlayersRequest) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchPlayersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SearchPlayersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Players []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+}
+
+func (x *SearchPlayersResponse) Reset() {
+	*x = SearchPlayersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPlayersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPlayersResponse) ProtoMessage() {}
+
+func (x *SearchPlayersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPlayersResponse.ProtoReflect.Descriptor instead.
+func (*SearchPlayersResponse) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchPlayersResponse) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type BatchGetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platform string   `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Tags     []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *BatchGetStatsRequest) Reset() {
+	*x = BatchGetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetStatsRequest) ProtoMessage() {}
+
+func (x *BatchGetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetStatsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetStatsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *BatchGetStatsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// BatchGetStatsResponse is the outcome of a single lookup of a batch
+type BatchGetStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Types that are assignable to Result:
+	//	*BatchGetStatsResponse_Stats
+	//	*BatchGetStatsResponse_Error
+	Result isBatchGetStatsResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *BatchGetStatsResponse) Reset() {
+	*x = BatchGetStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetStatsResponse) ProtoMessage() {}
+
+func (x *BatchGetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetStatsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetStatsResponse) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (m *BatchGetStatsResponse) GetResult() isBatchGetStatsResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *BatchGetStatsResponse) GetStats() *PlayerStats {
+	if x, ok := x.GetResult().(*BatchGetStatsResponse_Stats); ok {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *BatchGetStatsResponse) GetError() *Error {
+	if x, ok := x.GetResult().(*BatchGetStatsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isBatchGetStatsResponse_Result interface {
+	isBatchGetStatsResponse_Result()
+}
+
+type BatchGetStatsResponse_Stats struct {
+	Stats *PlayerStats `protobuf:"bytes,2,opt,name=stats,proto3,oneof"`
+}
+
+type BatchGetStatsResponse_Error struct {
+	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchGetStatsResponse_Stats) isBatchGetStatsResponse_Result() {}
+
+func (*BatchGetStatsResponse_Error) isBatchGetStatsResponse_Result() {}
+
+// Error is a failed lookup, with the same code and status as the problem the
+// REST API serves for it
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code       string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	HttpStatus int32  `protobuf:"varint,3,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetHttpStatus() int32 {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return 0
+}
+
+// PlayerStats holds all stats on a specified Overwatch player
+type PlayerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Icon             string           `protobuf:"bytes,1,opt,name=icon,proto3" json:"icon,omitempty"`
+	Name             string           `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Endorsement      int32            `protobuf:"varint,3,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
+	EndorsementIcon  string           `protobuf:"bytes,4,opt,name=endorsement_icon,json=endorsementIcon,proto3" json:"endorsement_icon,omitempty"`
+	Ratings          []*Rating        `protobuf:"bytes,5,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	GamesPlayed      int32            `protobuf:"varint,6,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	GamesWon         int32            `protobuf:"varint,7,opt,name=games_won,json=gamesWon,proto3" json:"games_won,omitempty"`
+	GamesLost        int32            `protobuf:"varint,8,opt,name=games_lost,json=gamesLost,proto3" json:"games_lost,omitempty"`
+	QuickPlayStats   *StatsCollection `protobuf:"bytes,9,opt,name=quick_play_stats,json=quickPlayStats,proto3" json:"quick_play_stats,omitempty"`
+	CompetitiveStats *StatsCollection `protobuf:"bytes,10,opt,name=competitive_stats,json=competitiveStats,proto3" json:"competitive_stats,omitempty"`
+	Private          bool             `protobuf:"varint,11,opt,name=private,proto3" json:"private,omitempty"`
+	Warnings         []*ParseWarning  `protobuf:"bytes,12,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (x *PlayerStats) Reset() {
+	*x = PlayerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStats) ProtoMessage() {}
+
+func (x *PlayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStats.ProtoReflect.Descriptor instead.
+func (*PlayerStats) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlayerStats) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *PlayerStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlayerStats) GetEndorsement() int32 {
+	if x != nil {
+		return x.Endorsement
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetEndorsementIcon() string {
+	if x != nil {
+		return x.EndorsementIcon
+	}
+	return ""
+}
+
+func (x *PlayerStats) GetRatings() []*Rating {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+func (x *PlayerStats) GetGamesPlayed() int32 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetGamesWon() int32 {
+	if x != nil {
+		return x.GamesWon
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetGamesLost() int32 {
+	if x != nil {
+		return x.GamesLost
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetQuickPlayStats() *StatsCollection {
+	if x != nil {
+		return x.QuickPlayStats
+	}
+	return nil
+}
+
+func (x *PlayerStats) GetCompetitiveStats() *StatsCollection {
+	if x != nil {
+		return x.CompetitiveStats
+	}
+	return nil
+}
+
+func (x *PlayerStats) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *PlayerStats) GetWarnings() []*ParseWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// ParseWarning describes a part of the career page that couldn't be parsed
+type ParseWarning struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	Field    string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ParseWarning) Reset() {
+	*x = ParseWarning{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseWarning) ProtoMessage() {}
+
+func (x *ParseWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseWarning.ProtoReflect.Descriptor instead.
+func (*ParseWarning) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ParseWarning) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *ParseWarning) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ParseWarning) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Rating struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group        string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Tier         int32  `protobuf:"varint,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	Role         string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	RoleIcon     string `protobuf:"bytes,4,opt,name=role_icon,json=roleIcon,proto3" json:"role_icon,omitempty"`
+	RankIcon     string `protobuf:"bytes,5,opt,name=rank_icon,json=rankIcon,proto3" json:"rank_icon,omitempty"`
+	DivisionIcon string `protobuf:"bytes,6,opt,name=division_icon,json=divisionIcon,proto3" json:"division_icon,omitempty"`
+}
+
+func (x *Rating) Reset() {
+	*x = Rating{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rating) ProtoMessage() {}
+
+func (x *Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rating.ProtoReflect.Descriptor instead.
+func (*Rating) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Rating) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Rating) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+func (x *Rating) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Rating) GetRoleIcon() string {
+	if x != nil {
+		return x.RoleIcon
+	}
+	return ""
+}
+
+func (x *Rating) GetRankIcon() string {
+	if x != nil {
+		return x.RankIcon
+	}
+	return ""
+}
+
+func (x *Rating) GetDivisionIcon() string {
+	if x != nil {
+		return x.DivisionIcon
+	}
+	return ""
+}
+
+// StatsCollection holds the stats of a play mode, keyed by hero
+type StatsCollection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Season is only set on competitive stats
+	Season      *int32                   `protobuf:"varint,1,opt,name=season,proto3,oneof" json:"season,omitempty"`
+	TopHeroes   map[string]*TopHeroStats `protobuf:"bytes,2,rep,name=top_heroes,json=topHeroes,proto3" json:"top_heroes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CareerStats map[string]*CareerStats  `protobuf:"bytes,3,rep,name=career_stats,json=careerStats,proto3" json:"career_stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StatsCollection) Reset() {
+	*x = StatsCollection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsCollection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsCollection) ProtoMessage() {}
+
+func (x *StatsCollection) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsCollection.ProtoReflect.Descriptor instead.
+func (*StatsCollection) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StatsCollection) GetSeason() int32 {
+	if x != nil && x.Season != nil {
+		return *x.Season
+	}
+	return 0
+}
+
+func (x *StatsCollection) GetTopHeroes() map[string]*TopHeroStats {
+	if x != nil {
+		return x.TopHeroes
+	}
+	return nil
+}
+
+func (x *StatsCollection) GetCareerStats() map[string]*CareerStats {
+	if x != nil {
+		return x.CareerStats
+	}
+	return nil
+}
+
+// TopHeroStats holds basic stats for each hero
+type TopHeroStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TimePlayed          string  `protobuf:"bytes,1,opt,name=time_played,json=timePlayed,proto3" json:"time_played,omitempty"`
+	TimePlayedSeconds   int64   `protobuf:"varint,2,opt,name=time_played_seconds,json=timePlayedSeconds,proto3" json:"time_played_seconds,omitempty"`
+	GamesWon            int32   `protobuf:"varint,3,opt,name=games_won,json=gamesWon,proto3" json:"games_won,omitempty"`
+	WeaponAccuracy      int32   `protobuf:"varint,4,opt,name=weapon_accuracy,json=weaponAccuracy,proto3" json:"weapon_accuracy,omitempty"`
+	CriticalHitAccuracy int32   `protobuf:"varint,5,opt,name=critical_hit_accuracy,json=criticalHitAccuracy,proto3" json:"critical_hit_accuracy,omitempty"`
+	EliminationsPerLife float64 `protobuf:"fixed64,6,opt,name=eliminations_per_life,json=eliminationsPerLife,proto3" json:"eliminations_per_life,omitempty"`
+	MultiKillBest       int32   `protobuf:"varint,7,opt,name=multi_kill_best,json=multiKillBest,proto3" json:"multi_kill_best,omitempty"`
+	ObjectiveKills      float64 `protobuf:"fixed64,8,opt,name=objective_kills,json=objectiveKills,proto3" json:"objective_kills,omitempty"`
+	// The accuracies above are rounded to whole percents, these keep the exact
+	// percentage shown on the career page
+	WeaponAccuracyPercent      float64 `protobuf:"fixed64,9,opt,name=weapon_accuracy_percent,json=weaponAccuracyPercent,proto3" json:"weapon_accuracy_percent,omitempty"`
+	CriticalHitAccuracyPercent float64 `protobuf:"fixed64,10,opt,name=critical_hit_accuracy_percent,json=criticalHitAccuracyPercent,proto3" json:"critical_hit_accuracy_percent,omitempty"`
+}
+
+func (x *TopHeroStats) Reset() {
+	*x = TopHeroStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopHeroStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopHeroStats) ProtoMessage() {}
+
+func (x *TopHeroStats) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopHeroStats.ProtoReflect.Descriptor instead.
+func (*TopHeroStats) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopHeroStats) GetTimePlayed() string {
+	if x != nil {
+		return x.TimePlayed
+	}
+	return ""
+}
+
+func (x *TopHeroStats) GetTimePlayedSeconds() int64 {
+	if x != nil {
+		return x.TimePlayedSeconds
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetGamesWon() int32 {
+	if x != nil {
+		return x.GamesWon
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetWeaponAccuracy() int32 {
+	if x != nil {
+		return x.WeaponAccuracy
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetCriticalHitAccuracy() int32 {
+	if x != nil {
+		return x.CriticalHitAccuracy
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetEliminationsPerLife() float64 {
+	if x != nil {
+		return x.EliminationsPerLife
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetMultiKillBest() int32 {
+	if x != nil {
+		return x.MultiKillBest
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetObjectiveKills() float64 {
+	if x != nil {
+		return x.ObjectiveKills
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetWeaponAccuracyPercent() float64 {
+	if x != nil {
+		return x.WeaponAccuracyPercent
+	}
+	return 0
+}
+
+func (x *TopHeroStats) GetCriticalHitAccuracyPercent() float64 {
+	if x != nil {
+		return x.CriticalHitAccuracyPercent
+	}
+	return 0
+}
+
+// CareerStats holds very detailed stats for each hero, keyed by category
+// such as "combat" or "best"
+type CareerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Categories map[string]*StatCategory `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CareerStats) Reset() {
+	*x = CareerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CareerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CareerStats) ProtoMessage() {}
+
+func (x *CareerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CareerStats.ProtoReflect.Descriptor instead.
+func (*CareerStats) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CareerStats) GetCategories() map[string]*StatCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// StatCategory holds the stats of a single career stats category, keyed by
+// stat such as "eliminations"
+type StatCategory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats map[string]*StatValue `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StatCategory) Reset() {
+	*x = StatCategory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatCategory) ProtoMessage() {}
+
+func (x *StatCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatCategory.ProtoReflect.Descriptor instead.
+func (*StatCategory) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StatCategory) GetStats() map[string]*StatValue {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// StatValue is a single career stat in typed form. Durations are in seconds
+// and percentages are the number shown (45 for 45%)
+type StatValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind    StatKind `protobuf:"varint,1,opt,name=kind,proto3,enum=ovrstat.v1.StatKind" json:"kind,omitempty"`
+	Value   float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Display string   `protobuf:"bytes,3,opt,name=display,proto3" json:"display,omitempty"`
+}
+
+func (x *StatValue) Reset() {
+	*x = StatValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatValue) ProtoMessage() {}
+
+func (x *StatValue) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatValue.ProtoReflect.Descriptor instead.
+func (*StatValue) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StatValue) GetKind() StatKind {
+	if x != nil {
+		return x.Kind
+	}
+	return StatKind_STAT_KIND_UNSPECIFIED
+}
+
+func (x *StatValue) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *StatValue) GetDisplay() string {
+	if x != nil {
+		return x.Display
+	}
+	return ""
+}
+
+// Player is a player matching a search
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BattleTag string `protobuf:"bytes,1,opt,name=battle_tag,json=battleTag,proto3" json:"battle_tag,omitempty"`
+	Portrait  string `protobuf:"bytes,2,opt,name=portrait,proto3" json:"portrait,omitempty"`
+	Frame     string `protobuf:"bytes,3,opt,name=frame,proto3" json:"frame,omitempty"`
+	IsPublic  bool   `protobuf:"varint,4,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
+	Url       string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ovrstat_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_ovrstat_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_ovrstat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Player) GetBattleTag() string {
+	if x != nil {
+		return x.BattleTag
+	}
+	return ""
+}
+
+func (x *Player) GetPortrait() string {
+	if x != nil {
+		return x.Portrait
+	}
+	return ""
+}
+
+func (x *Player) GetFrame() string {
+	if x != nil {
+		return x.Frame
+	}
+	return ""
+}
+
+func (x *Player) GetIsPublic() bool {
+	if x != nil {
+		return x.IsPublic
+	}
+	return false
+}
+
+func (x *Player) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+var File_ovrstat_proto protoreflect.FileDescriptor
+
+var file_ovrstat_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0a, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x22, 0x3f, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x2a, 0x0a, 0x14,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x45, 0x0a, 0x15, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2c, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22,
+	0x46, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x15, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42,
+	0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x56, 0x0a, 0x05, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0xf0, 0x03, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x63, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x63, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x67, 0x61, 0x6d, 0x65,
+	0x73, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x73,
+	0x5f, 0x77, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65,
+	0x73, 0x57, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x6c, 0x6f,
+	0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x4c,
+	0x6f, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x10, 0x71, 0x75, 0x69, 0x63, 0x6b, 0x5f, 0x70, 0x6c, 0x61,
+	0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x71, 0x75, 0x69, 0x63,
+	0x6b, 0x50, 0x6c, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x48, 0x0a, 0x11, 0x63, 0x6f,
+	0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x76, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x34,
+	0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x72, 0x73, 0x65, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x22, 0x58, 0x0a, 0x0c, 0x50, 0x61, 0x72, 0x73, 0x65, 0x57, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xa5,
+	0x01, 0x0a, 0x06, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74,
+	0x69, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
+	0x69, 0x63, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65,
+	0x49, 0x63, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x69, 0x63, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x6b, 0x49, 0x63, 0x6f,
+	0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x63,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x63, 0x6f, 0x6e, 0x22, 0x86, 0x03, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x49, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x5f, 0x68,
+	0x65, 0x72, 0x6f, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6f, 0x76,
+	0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x6f, 0x70, 0x48, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x12, 0x4f, 0x0a, 0x0c, 0x63, 0x61, 0x72, 0x65, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74,
+	0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x72, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x65, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x1a, 0x56, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x48, 0x65, 0x72, 0x6f, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x48, 0x65, 0x72, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57, 0x0a, 0x10, 0x43,
+	0x61, 0x72, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
+	0x72, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0xd9, 0x03, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x48, 0x65, 0x72, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x64, 0x12, 0x2e, 0x0a, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x74, 0x69, 0x6d, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x77, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x57, 0x6f, 0x6e, 0x12, 0x27,
+	0x0a, 0x0f, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x5f, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x41,
+	0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x72, 0x69, 0x74, 0x69,
+	0x63, 0x61, 0x6c, 0x5f, 0x68, 0x69, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c,
+	0x48, 0x69, 0x74, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x65,
+	0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x6c, 0x69, 0x66, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x65, 0x6c, 0x69, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x4c, 0x69, 0x66, 0x65, 0x12,
+	0x26, 0x0a, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x62, 0x65,
+	0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x4b,
+	0x69, 0x6c, 0x6c, 0x42, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4b, 0x69, 0x6c, 0x6c, 0x73,
+	0x12, 0x36, 0x0a, 0x17, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x5f, 0x61, 0x63, 0x63, 0x75, 0x72,
+	0x61, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x15, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63,
+	0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x1d, 0x63, 0x72, 0x69, 0x74,
+	0x69, 0x63, 0x61, 0x6c, 0x5f, 0x68, 0x69, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63,
+	0x79, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x1a, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x48, 0x69, 0x74, 0x41, 0x63, 0x63, 0x75,
+	0x72, 0x61, 0x63, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0xaf, 0x01, 0x0a, 0x0b,
+	0x43, 0x61, 0x72, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x47, 0x0a, 0x0a, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72,
+	0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x1a, 0x57, 0x0a, 0x0f, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74,
+	0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9a, 0x01,
+	0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x39,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x43,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x1a, 0x4f, 0x0a, 0x0a, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74,
+	0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x09, 0x53, 0x74,
+	0x61, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x22, 0x88, 0x01, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
+	0x62, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x62, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x6f, 0x72, 0x74, 0x72, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6f, 0x72, 0x74, 0x72, 0x61, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x69, 0x73, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x69, 0x73, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x2a, 0x95, 0x01, 0x0a,
+	0x08, 0x53, 0x74, 0x61, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x54, 0x41,
+	0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54, 0x41, 0x54, 0x5f, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x41,
+	0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x44, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x02, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x54, 0x41, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x50,
+	0x45, 0x52, 0x43, 0x45, 0x4e, 0x54, 0x41, 0x47, 0x45, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x53,
+	0x54, 0x41, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x10, 0x04,
+	0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x54, 0x45,
+	0x58, 0x54, 0x10, 0x05, 0x32, 0xf9, 0x01, 0x0a, 0x07, 0x4f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74,
+	0x12, 0x40, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x6f,
+	0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6f, 0x76, 0x72, 0x73,
+	0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x54, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x12, 0x20, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x6f, 0x76, 0x72, 0x73,
+	0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x76,
+	0x72, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
+	0x77, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x76, 0x72, 0x73, 0x74, 0x61, 0x74, 0x2f, 0x6f, 0x76,
+	0x72, 0x73, 0x74, 0x61, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ovrstat_proto_rawDescOnce sync.Once
+	file_ovrstat_proto_rawDescData = file_ovrstat_proto_rawDesc
+)
+
+func file_ovrstat_proto_rawDescGZIP() []byte {
+	file_ovrstat_proto_rawDescOnce.Do(func() {
+		file_ovrstat_proto_rawDescData = protoimpl.X.CompressGZIP(file_ovrstat_proto_rawDescData)
+	})
+	return file_ovrstat_proto_rawDescData
+}
+
+var file_ovrstat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ovrstat_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_ovrstat_proto_goTypes = []interface{}{
+	(StatKind)(0),                 // 0: ovrstat.v1.StatKind
+	(*GetStatsRequest)(nil),       // 1: ovrstat.v1.GetStatsRequest
+	(*SearchPlayersRequest)(nil),  // 2: ovrstat.v1.SearchPlayersRequest
+	(*SearchPlayersResponse)(nil), // 3: ovrstat.v1.SearchPlayersResponse
+	(*BatchGetStatsRequest)(nil),  // 4: ovrstat.v1.BatchGetStatsRequest
+	(*BatchGetStatsResponse)(nil), // 5: ovrstat.v1.BatchGetStatsResponse
+	(*Error)(nil),                 // 6: ovrstat.v1.Error
+	(*PlayerStats)(nil),           // 7: ovrstat.v1.PlayerStats
+	(*ParseWarning)(nil),          // 8: ovrstat.v1.ParseWarning
+	(*Rating)(nil),                // 9: ovrstat.v1.Rating
+	(*StatsCollection)(nil),       // 10: ovrstat.v1.StatsCollection
+	(*TopHeroStats)(nil),          // 11: ovrstat.v1.TopHeroStats
+	(*CareerStats)(nil),           // 12: ovrstat.v1.CareerStats
+	(*StatCategory)(nil),          // 13: ovrstat.v1.StatCategory
+	(*StatValue)(nil),             // 14: ovrstat.v1.StatValue
+	(*Player)(nil),                // 15: ovrstat.v1.Player
+	nil,                           // 16: ovrstat.v1.StatsCollection.TopHeroesEntry
+	nil,                           // 17: ovrstat.v1.StatsCollection.CareerStatsEntry
+	nil,                           // 18: ovrstat.v1.CareerStats.CategoriesEntry
+	nil,                           // 19: ovrstat.v1.StatCategory.StatsEntry
+}
+var file_ovrstat_proto_depIdxs = []int32{
+	15, // 0: ovrstat.v1.SearchPlayersResponse.players:type_name -> ovrstat.v1.Player
+	7,  // 1: ovrstat.v1.BatchGetStatsResponse.stats:type_name -> ovrstat.v1.PlayerStats
+	6,  // 2: ovrstat.v1.BatchGetStatsResponse.error:type_name -> ovrstat.v1.Error
+	9,  // 3: ovrstat.v1.PlayerStats.ratings:type_name -> ovrstat.v1.Rating
+	10, // 4: ovrstat.v1.PlayerStats.quick_play_stats:type_name -> ovrstat.v1.StatsCollection
+	10, // 5: ovrstat.v1.PlayerStats.competitive_stats:type_name -> ovrstat.v1.StatsCollection
+	8,  // 6: ovrstat.v1.PlayerStats.warnings:type_name -> ovrstat.v1.ParseWarning
+	16, // 7: ovrstat.v1.StatsCollection.top_heroes:type_name -> ovrstat.v1.StatsCollection.TopHeroesEntry
+	17, // 8: ovrstat.v1.StatsCollection.career_stats:type_name -> ovrstat.v1.StatsCollection.CareerStatsEntry
+	18, // 9: ovrstat.v1.CareerStats.categories:type_name -> ovrstat.v1.CareerStats.CategoriesEntry
+	19, // 10: ovrstat.v1.StatCategory.stats:type_name -> ovrstat.v1.StatCategory.StatsEntry
+	0,  // 11: ovrstat.v1.StatValue.kind:type_name -> ovrstat.v1.StatKind
+	11, // 12: ovrstat.v1.StatsCollection.TopHeroesEntry.value:type_name -> ovrstat.v1.TopHeroStats
+	12, // 13: ovrstat.v1.StatsCollection.CareerStatsEntry.value:type_name -> ovrstat.v1.CareerStats
+	13, // 14: ovrstat.v1.CareerStats.CategoriesEntry.value:type_name -> ovrstat.v1.StatCategory
+	14, // 15: ovrstat.v1.StatCategory.StatsEntry.value:type_name -> ovrstat.v1.StatValue
+	1,  // 16: ovrstat.v1.Ovrstat.GetStats:input_type -> ovrstat.v1.GetStatsRequest
+	2,  // 17: ovrstat.v1.Ovrstat.SearchPlayers:input_type -> ovrstat.v1.SearchPlayersRequest
+	4,  // 18: ovrstat.v1.Ovrstat.BatchGetStats:input_type -> ovrstat.v1.BatchGetStatsRequest
+	7,  // 19: ovrstat.v1.Ovrstat.GetStats:output_type -> ovrstat.v1.PlayerStats
+	3,  // 20: ovrstat.v1.Ovrstat.SearchPlayers:output_type -> ovrstat.v1.SearchPlayersResponse
+	5,  // 21: ovrstat.v1.Ovrstat.BatchGetStats:output_type -> ovrstat.v1.BatchGetStatsResponse
+	19, // [19:22] is the sub-list for method output_type
+	16, // [16:19] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_ovrstat_proto_init() }
+func file_ovrstat_proto_init() {
+	if File_ovrstat_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ovrstat_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPlayersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPlayersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParseWarning); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rating); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsCollection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopHeroStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CareerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatCategory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ovrstat_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_ovrstat_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*BatchGetStatsResponse_Stats)(nil),
+		(*BatchGetStatsResponse_Error)(nil),
+	}
+	file_ovrstat_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ovrstat_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ovrstat_proto_goTypes,
+		DependencyIndexes: file_ovrstat_proto_depIdxs,
+		EnumInfos:         file_ovrstat_proto_enumTypes,
+		MessageInfos:      file_ovrstat_proto_msgTypes,
+	}.Build()
+	File_ovrstat_proto = out.File
+	file_ovrstat_proto_rawDesc = nil
+	file_ovrstat_proto_goTypes = nil
+	file_ovrstat_proto_depIdxs = nil
+}
@@ -0,0 +1,219 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: ovrstat.proto
+
+package ovrstatpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Ovrstat_GetStats_FullMethodName      = "/ovrstat.v1.Ovrstat/GetStats"
+	Ovrstat_SearchPlayers_FullMethodName = "/ovrstat.v1.Ovrstat/SearchPlayers"
+	Ovrstat_BatchGetStats_FullMethodName = "/ovrstat.v1.Ovrstat/BatchGetStats"
+)
+
+// OvrstatClient is the client API for Ovrstat service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OvrstatClient interface {
+	// GetStats returns the stats of a player on a single platform
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*PlayerStats, error)
+	// SearchPlayers returns every player matching a name
+	SearchPlayers(ctx context.Context, in *SearchPlayersRequest, opts ...grpc.CallOption) (*SearchPlayersResponse, error)
+	// BatchGetStats looks up the stats of several players on a single platform,
+	// streaming each result as soon as its lookup completes
+	BatchGetStats(ctx context.Context, in *BatchGetStatsRequest, opts ...grpc.CallOption) (Ovrstat_BatchGetStatsClient, error)
+}
+
+type ovrstatClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOvrstatClient(cc grpc.ClientConnInterface) OvrstatClient {
+	return &ovrstatClient{cc}
+}
+
+func (c *ovrstatClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*PlayerStats, error) {
+	out := new(PlayerStats)
+	err := c.cc.Invoke(ctx, Ovrstat_GetStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ovrstatClient) SearchPlayers(ctx context.Context, in *SearchPlayersRequest, opts ...grpc.CallOption) (*SearchPlayersResponse, error) {
+	out := new(SearchPlayersResponse)
+	err := c.cc.Invoke(ctx, Ovrstat_SearchPlayers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ovrstatClient) BatchGetStats(ctx context.Context, in *BatchGetStatsRequest, opts ...grpc.CallOption) (Ovrstat_BatchGetStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Ovrstat_ServiceDesc.Streams[0], Ovrstat_BatchGetStats_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ovrstatBatchGetStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ovrstat_BatchGetStatsClient interface {
+	Recv() (*BatchGetStatsResponse, error)
+	grpc.ClientStream
+}
+
+type ovrstatBatchGetStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *ovrstatBatchGetStatsClient) Recv() (*BatchGetStatsResponse, error) {
+	m := new(BatchGetStatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OvrstatServer is the server API for Ovrstat service.
+// All implementations must embed UnimplementedOvrstatServer
+// for forward compatibility
+type OvrstatServer interface {
+	// GetStats returns the stats of a player on a single platform
+	GetStats(context.Context, *GetStatsRequest) (*PlayerStats, error)
+	// SearchPlayers returns every player matching a name
+	SearchPlayers(context.Context, *SearchPlayersRequest) (*SearchPlayersResponse, error)
+	// BatchGetStats looks up the stats of several players on a single platform,
+	// streaming each result as soon as its lookup completes
+	BatchGetStats(*BatchGetStatsRequest, Ovrstat_BatchGetStatsServer) error
+	mustEmbedUnimplementedOvrstatServer()
+}
+
+// UnimplementedOvrstatServer must be embedded to have forward compatible implementations.
+type UnimplementedOvrstatServer struct {
+}
+
+func (UnimplementedOvrstatServer) GetStats(context.Context, *GetStatsRequest) (*PlayerStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedOvrstatServer) SearchPlayers(context.Context, *SearchPlayersRequest) (*SearchPlayersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPlayers not implemented")
+}
+func (UnimplementedOvrstatServer) BatchGetStats(*BatchGetStatsRequest, Ovrstat_BatchGetStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchGetStats not implemented")
+}
+func (UnimplementedOvrstatServer) mustEmbedUnimplementedOvrstatServer() {}
+
+// UnsafeOvrstatServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OvrstatServer will
+// result in compilation errors.
+type UnsafeOvrstatServer interface {
+	mustEmbedUnimplementedOvrstatServer()
+}
+
+func RegisterOvrstatServer(s grpc.ServiceRegistrar, srv OvrstatServer) {
+	s.RegisterService(&Ovrstat_ServiceDesc, srv)
+}
+
+func _Ovrstat_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OvrstatServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ovrstat_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OvrstatServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ovrstat_SearchPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OvrstatServer).SearchPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ovrstat_SearchPlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OvrstatServer).SearchPlayers(ctx, req.(*SearchPlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ovrstat_BatchGetStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchGetStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OvrstatServer).BatchGetStats(m, &ovrstatBatchGetStatsServer{stream})
+}
+
+type Ovrstat_BatchGetStatsServer interface {
+	Send(*BatchGetStatsResponse) error
+	grpc.ServerStream
+}
+
+type ovrstatBatchGetStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *ovrstatBatchGetStatsServer) Send(m *BatchGetStatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Ovrstat_ServiceDesc is the grpc.ServiceDesc for Ovrstat service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ovrstat_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ovrstat.v1.Ovrstat",
+	HandlerType: (*OvrstatServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStats",
+			Handler:    _Ovrstat_GetStats_Handler,
+		},
+		{
+			MethodName: "SearchPlayers",
+			Handler:    _Ovrstat_SearchPlayers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchGetStats",
+			Handler:       _Ovrstat_BatchGetStats_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ovrstat.proto",
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// apiKey is a client allowed past the anonymous rate limit, as loaded from
+// the API keys file
+type apiKey struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+
+	// Rate and Burst override the default key rate limit when set. A key
+	// setting only its rate keeps the default burst
+	Rate  float64 `json:"rate,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// loadAPIKeys reads the API keys file at the passed path, a JSON array of keys
+// such as [{"key": "...", "name": "my-app", "rate": 10, "burst": 20}], and
+// returns the keys by their value. An empty path loads no keys
+func loadAPIKeys(path string) (map[string]*apiKey, error) {
+	keys := make(map[string]*apiKey)
+	if path == "" {
+		return keys, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the API keys file")
+	}
+
+	var list []*apiKey
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the API keys file")
+	}
+
+	for i, k := range list {
+		if k.Key == "" {
+			return nil, errors.Errorf("API key %d has no key", i)
+		}
+		if k.Rate < 0 || k.Burst < 0 {
+			return nil, errors.Errorf("API key %q has a negative rate or burst", k.Name)
+		}
+		if _, ok := keys[k.Key]; ok {
+			return nil, errors.Errorf("API key %q is listed twice", k.Name)
+		}
+		keys[k.Key] = k
+	}
+
+	return keys, nil
+}
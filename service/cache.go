@@ -0,0 +1,447 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+	"github.com/pkg/errors"
+)
+
+const (
+	// headerXCache reports whether a response was served from the cache, one
+	// of HIT, STALE or MISS
+	headerXCache = "X-Cache"
+
+	// headerAge is the age of cached data in seconds
+	headerAge = "Age"
+
+	// refreshTimeout bounds how long a background refresh may take
+	refreshTimeout = 30 * time.Second
+
+	// cacheFormat is the version of the entries encoded by the backends
+	// storing them outside the process. It's bumped whenever entries of the
+	// previous format would decode to different data, such as when the typed
+	// career stats were added and then moved out of the JSON of the stats,
+	// so they're looked up again instead
+	cacheFormat = 2
+)
+
+// cacheEntry is the cached result of a lookup, either stats or the fact the
+// player, or their stats on the platform, weren't found
+type cacheEntry struct {
+	Stats    *ovrstat.PlayerStats  `json:"stats,omitempty"`
+	Profile  *ovrstat.ProfileStats `json:"profile,omitempty"`
+	NotFound bool                  `json:"notFound,omitempty"`
+	StoredAt time.Time             `json:"storedAt"`
+	Expires  time.Time             `json:"expires"`
+
+	// PlatformNotFound records the player has no stats on the platform
+	PlatformNotFound bool `json:"platformNotFound,omitempty"`
+
+	// StaleUntil is when the entry stops being servable as stale data
+	StaleUntil time.Time `json:"staleUntil"`
+
+	// Format is the cacheFormat the entry was encoded with
+	Format int `json:"format"`
+
+	// StatsValues and ProfileValues hold the typed career stats of the entry,
+	// which the JSON form of the stats leaves out, by platform for profiles.
+	// They're only set on encoded entries
+	StatsValues   typedStats            `json:"statsValues,omitempty"`
+	ProfileValues map[string]typedStats `json:"profileValues,omitempty"`
+}
+
+// typedStats holds the typed career stats of a PlayerStats by play mode and
+// hero
+type typedStats map[string]map[string]ovrstat.StatValues
+
+// typedStatsOf returns the typed career stats of the passed stats
+func typedStatsOf(stats *ovrstat.PlayerStats) typedStats {
+	typed := make(typedStats)
+	for mode, collection := range statsCollections(stats) {
+		for hero, cs := range collection.CareerStats {
+			if cs == nil || cs.Values == nil {
+				continue
+			}
+			if typed[mode] == nil {
+				typed[mode] = make(map[string]ovrstat.StatValues)
+			}
+			typed[mode][hero] = cs.Values
+		}
+	}
+	return typed
+}
+
+// restore sets the typed career stats back on the passed stats
+func (t typedStats) restore(stats *ovrstat.PlayerStats) {
+	for mode, collection := range statsCollections(stats) {
+		for hero, cs := range collection.CareerStats {
+			if cs != nil {
+				cs.Values = t[mode][hero]
+			}
+		}
+	}
+}
+
+// statsCollections returns the stats of every play mode of the passed stats,
+// keyed by their JSON name
+func statsCollections(stats *ovrstat.PlayerStats) map[string]*ovrstat.StatsCollection {
+	return map[string]*ovrstat.StatsCollection{
+		"quickPlayStats":   &stats.QuickPlayStats.StatsCollection,
+		"competitiveStats": &stats.CompetitiveStats.StatsCollection,
+	}
+}
+
+// encodeEntry encodes an entry for the backends storing them outside the
+// process, along with the typed career stats of its stats
+func encodeEntry(entry *cacheEntry) ([]byte, error) {
+	e := *entry
+	e.Format = cacheFormat
+
+	if e.Stats != nil {
+		e.StatsValues = typedStatsOf(e.Stats)
+	}
+	if e.Profile != nil {
+		e.ProfileValues = make(map[string]typedStats, len(e.Profile.Stats))
+		for platform, stats := range e.Profile.Stats {
+			if stats != nil {
+				e.ProfileValues[platform] = typedStatsOf(stats)
+			}
+		}
+	}
+
+	return json.Marshal(&e)
+}
+
+// decodeEntry decodes an entry encoded by encodeEntry, reporting whether it
+// can be served. Entries of another format or past StaleUntil can't
+func decodeEntry(v []byte) (*cacheEntry, bool, error) {
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(v, entry); err != nil {
+		return nil, false, err
+	}
+
+	if entry.Format != cacheFormat || time.Now().After(entry.StaleUntil) {
+		return nil, false, nil
+	}
+
+	if entry.Stats != nil {
+		entry.StatsValues.restore(entry.Stats)
+	}
+	if entry.Profile != nil {
+		for platform, stats := range entry.Profile.Stats {
+			if stats != nil {
+				entry.ProfileValues[platform].restore(stats)
+			}
+		}
+	}
+	entry.StatsValues, entry.ProfileValues = nil, nil
+
+	return entry, true, nil
+}
+
+// err returns ovrstat.ErrPlayerNotFound or ovrstat.ErrPlatformNotFound when
+// the entry records the player or their stats on the platform weren't found
+func (e *cacheEntry) err() error {
+	switch {
+	case e.NotFound:
+		return ovrstat.ErrPlayerNotFound
+	case e.PlatformNotFound:
+		return ovrstat.ErrPlatformNotFound
+	}
+	return nil
+}
+
+// private reports whether the entry is a private profile
+func (e *cacheEntry) private() bool {
+	return (e.Stats != nil && e.Stats.Private) || (e.Profile != nil && e.Profile.Private)
+}
+
+// cacheBackend stores lookup results. Entries are kept until they can't be
+// served as stale data anymore, after which they're treated as missing.
+// Implementations must be safe for concurrent use
+type cacheBackend interface {
+	// get returns the entry stored under the passed key
+	get(ctx context.Context, key string) (*cacheEntry, bool, error)
+
+	// set stores the passed entry under the passed key
+	set(ctx context.Context, key string, entry *cacheEntry) error
+
+	// close releases the resources held by the backend
+	close() error
+}
+
+// Cache backends selectable with Config.CacheBackend
+const (
+	CacheMemory = "memory"
+	CacheDisk   = "disk"
+	CacheRedis  = "redis"
+)
+
+// newCacheBackend opens the cache backend selected by the passed config
+func newCacheBackend(cfg Config) (cacheBackend, error) {
+	switch cfg.CacheBackend {
+	case CacheMemory, "":
+		return newLRUCache(cfg.CacheSize), nil
+	case CacheDisk:
+		return openBoltCache(cfg.CachePath)
+	case CacheRedis:
+		return openRedisCache(cfg.CacheURL)
+	}
+	return nil, errors.Errorf("Unknown cache backend %q", cfg.CacheBackend)
+}
+
+// lruCache is a bounded, least recently used, in-memory cacheBackend
+type lruCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// lruItem is the value of every element in the lruCache list
+type lruItem struct {
+	key   string
+	entry *cacheEntry
+}
+
+// newLRUCache creates and returns a new lruCache holding at most size entries.
+// A size of 0 or less disables caching
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry stored under the passed key
+func (c *lruCache) get(_ context.Context, key string) (*cacheEntry, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	item := el.Value.(*lruItem)
+
+	if time.Now().After(item.entry.StaleUntil) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false, nil
+	}
+
+	c.ll.MoveToFront(el)
+	return item.entry, true, nil
+}
+
+// set stores the passed entry under the passed key, evicting the least
+// recently used entry when the cache is full
+func (c *lruCache) set(_ context.Context, key string, entry *cacheEntry) error {
+	if c.size <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		c.ll.MoveToFront(el)
+		return nil
+	}
+
+	c.items[key] = c.ll.PushFront(&lruItem{key: key, entry: entry})
+
+	for c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.items, el.Value.(*lruItem).key)
+	}
+	return nil
+}
+
+// close is a no-op, the entries are simply dropped with the cache
+func (c *lruCache) close() error {
+	return nil
+}
+
+// cacheKey returns the key a lookup is cached under, built from the lookup
+// kind, the platform and the normalized battletag. The platform must already
+// be normalized with normalizePlatform, as it's what the lookup is made with
+func cacheKey(kind, platform, tag string) (string, error) {
+	bt, err := ovrstat.ParseBattleTag(tag)
+	if err != nil {
+		return "", err
+	}
+	return kind + ":" + platform + ":" + bt.Key(), nil
+}
+
+// normalizePlatform returns the platform of a request as ovrstat expects it,
+// so "PC" is looked up and cached like "pc". Unknown platforms fail with
+// ovrstat.ErrInvalidPlatform before anything is requested upstream
+func normalizePlatform(platform string) (string, error) {
+	switch platform = strings.ToLower(platform); platform {
+	case ovrstat.PlatformPC, ovrstat.PlatformConsole:
+		return platform, nil
+	}
+	return "", ovrstat.ErrInvalidPlatform
+}
+
+// fetchFunc performs an uncached lookup. It may outlive the request that
+// triggered it, so it must not use the request's echo.Context
+type fetchFunc func(ctx context.Context) (*cacheEntry, error)
+
+// cachedLookup performs a lookup like lookup, setting the X-Cache header to
+// report how it was served along with the age of cached entries
+func (s *server) cachedLookup(c echo.Context, key string, fetch fetchFunc) (*cacheEntry, error) {
+	entry, status, err := s.lookup(c.Request().Context(), key, fetch)
+
+	h := c.Response().Header()
+	h.Set(headerXCache, status)
+
+	if status != "MISS" {
+		h.Set(headerAge, strconv.Itoa(int(time.Since(entry.StoredAt).Seconds())))
+		h.Set(echo.HeaderLastModified, entry.StoredAt.UTC().Format(http.TimeFormat))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// lookup returns the entry cached under the passed key, or performs the passed
+// fetch and caches its result. Expired entries are still served while they're
+// refreshed in the background (stale-while-revalidate) or when the refresh
+// fails upstream (stale-if-error). The status returned reports which, one of
+// HIT, STALE or MISS. Cached entries are returned even when they record the
+// player wasn't found
+func (s *server) lookup(ctx context.Context, key string, fetch fetchFunc) (*cacheEntry, string, error) {
+	now := time.Now()
+
+	// A failing cache degrades to uncached lookups rather than failing them
+	cached, ok, err := s.cache.get(ctx, key)
+	if err != nil {
+		s.logger.Warnf("Failed to read %s from the cache: %s", key, err)
+	}
+
+	if ok && now.Before(cached.Expires) {
+		return cached, "HIT", cached.err()
+	}
+
+	if ok && now.Before(cached.Expires.Add(s.cfg.StaleWhileRevalidate)) {
+		s.revalidate(key, fetch)
+		return cached, "STALE", cached.err()
+	}
+
+	entry, err := s.store(ctx, key, fetch)
+	if err != nil {
+		var upstream *ovrstat.UpstreamError
+
+		if ok && errors.As(err, &upstream) && now.Before(cached.Expires.Add(s.cfg.StaleIfError)) {
+			s.logger.Warnf("Serving stale %s: %s", key, err)
+			return cached, "STALE", cached.err()
+		}
+	}
+
+	return entry, "MISS", err
+}
+
+// store performs the passed fetch and caches its result, coalesced with any
+// identical lookup already in flight. Errors other than the player or their
+// stats on the platform not being found aren't cached
+func (s *server) store(ctx context.Context, key string, fetch fetchFunc) (*cacheEntry, error) {
+	return s.flights.do(ctx, key, func(ctx context.Context) (*cacheEntry, error) {
+		return s.fetchAndCache(ctx, key, fetch)
+	})
+}
+
+// fetchAndCache performs the passed fetch and caches its result
+func (s *server) fetchAndCache(ctx context.Context, key string, fetch fetchFunc) (*cacheEntry, error) {
+	now := time.Now()
+
+	entry, err := fetch(ctx)
+	if err != nil {
+		if s.cfg.NotFoundTTL > 0 {
+			switch {
+			case errors.Is(err, ovrstat.ErrPlayerNotFound):
+				s.saveEntry(ctx, key, s.expiring(&cacheEntry{NotFound: true}, now, s.cfg.NotFoundTTL))
+			case errors.Is(err, ovrstat.ErrPlatformNotFound):
+				s.saveEntry(ctx, key, s.expiring(&cacheEntry{PlatformNotFound: true}, now, s.cfg.NotFoundTTL))
+			}
+		}
+		return nil, err
+	}
+
+	ttl := s.cfg.CacheTTL
+	if entry.private() {
+		ttl = s.cfg.PrivateTTL
+	}
+
+	if ttl > 0 {
+		s.saveEntry(ctx, key, s.expiring(entry, now, ttl))
+	}
+
+	return entry, nil
+}
+
+// saveEntry stores the passed entry in the cache, logging any failure
+func (s *server) saveEntry(ctx context.Context, key string, entry *cacheEntry) {
+	if err := s.cache.set(ctx, key, entry); err != nil {
+		s.logger.Warnf("Failed to write %s to the cache: %s", key, err)
+	}
+}
+
+// expiring sets the times the passed entry was stored at, expires and stops
+// being servable as stale data
+func (s *server) expiring(entry *cacheEntry, now time.Time, ttl time.Duration) *cacheEntry {
+	grace := s.cfg.StaleWhileRevalidate
+	if s.cfg.StaleIfError > grace {
+		grace = s.cfg.StaleIfError
+	}
+
+	entry.StoredAt = now
+	entry.Expires = now.Add(ttl)
+	entry.StaleUntil = entry.Expires.Add(grace)
+
+	return entry
+}
+
+// revalidate refreshes the entry cached under the passed key in the
+// background, unless it's already being refreshed. A failed refresh leaves the
+// stale entry in place
+func (s *server) revalidate(key string, fetch fetchFunc) {
+	s.mu.Lock()
+	if s.refreshing[key] {
+		s.mu.Unlock()
+		return
+	}
+	s.refreshing[key] = true
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.refreshing, key)
+			s.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		if _, err := s.store(ctx, key, fetch); err != nil {
+			s.logger.Warnf("Failed to refresh %s: %s", key, err)
+		}
+	}()
+}
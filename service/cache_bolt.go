@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// boltBucket is the bucket every entry of a boltCache is stored in
+	boltBucket = "entries"
+
+	// boltSweepInterval is how often a boltCache deletes the entries that
+	// can't be served anymore
+	boltSweepInterval = 10 * time.Minute
+)
+
+// boltCache is a cacheBackend storing JSON encoded entries in an embedded bolt
+// database on disk, so they survive restarts
+type boltCache struct {
+	db   *bolt.DB
+	stop chan struct{}
+}
+
+// openBoltCache opens or creates the bolt database at the passed path and
+// starts sweeping it of entries that can't be served anymore
+func openBoltCache(path string) (*boltCache, error) {
+	if path == "" {
+		return nil, errors.New("No cache path configured for the disk cache")
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to open the disk cache")
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(boltBucket))
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "Failed to create the disk cache bucket")
+	}
+
+	c := &boltCache{db: db, stop: make(chan struct{})}
+	go c.sweepEvery(boltSweepInterval)
+
+	return c, nil
+}
+
+// get returns the entry stored under the passed key
+func (c *boltCache) get(_ context.Context, key string) (*cacheEntry, bool, error) {
+	var (
+		entry *cacheEntry
+		ok    bool
+	)
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(boltBucket)).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+
+		var err error
+		entry, ok, err = decodeEntry(v)
+		return err
+	})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return entry, true, nil
+}
+
+// set stores the passed entry under the passed key
+func (c *boltCache) set(_ context.Context, key string, entry *cacheEntry) error {
+	v, err := encodeEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(boltBucket)).Put([]byte(key), v)
+	})
+}
+
+// sweep deletes every entry that can't be served anymore
+func (c *boltCache) sweep() error {
+	now := time.Now()
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		cur := tx.Bucket([]byte(boltBucket)).Cursor()
+
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			var entry struct {
+				StaleUntil time.Time `json:"staleUntil"`
+				Format     int       `json:"format"`
+			}
+
+			// Entries that can't be decoded, or are of another format,
+			// can't be served either
+			if err := json.Unmarshal(v, &entry); err == nil && entry.Format == cacheFormat && now.Before(entry.StaleUntil) {
+				continue
+			}
+
+			if err := cur.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// sweepEvery sweeps the cache at the passed interval until it's closed
+func (c *boltCache) sweepEvery(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			c.sweep()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// close stops sweeping and closes the database
+func (c *boltCache) close() error {
+	close(c.stop)
+	return c.db.Close()
+}
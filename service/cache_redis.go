@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces the keys of a redisCache
+const redisKeyPrefix = "ovrstat:"
+
+// redisCache is a cacheBackend storing JSON encoded entries in Redis, shared by
+// every replica of the service pointed at it. Entries are given a TTL, so
+// Redis drops them once they can't be served anymore
+type redisCache struct {
+	client *redis.Client
+}
+
+// openRedisCache creates a redisCache connecting to the passed Redis URL, such
+// as "redis://localhost:6379/0". Connections are only made once the cache is
+// used
+func openRedisCache(url string) (*redisCache, error) {
+	if url == "" {
+		return nil, errors.New("No cache URL configured for the redis cache")
+	}
+
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid redis cache URL")
+	}
+
+	return &redisCache{client: redis.NewClient(opts)}, nil
+}
+
+// get returns the entry stored under the passed key
+func (c *redisCache) get(ctx context.Context, key string) (*cacheEntry, bool, error) {
+	v, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return decodeEntry(v)
+}
+
+// set stores the passed entry under the passed key
+func (c *redisCache) set(ctx context.Context, key string, entry *cacheEntry) error {
+	ttl := time.Until(entry.StaleUntil)
+	if ttl <= 0 {
+		return nil
+	}
+
+	v, err := encodeEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, redisKeyPrefix+key, v, ttl).Err()
+}
+
+// close closes the connections to Redis
+func (c *redisCache) close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ow-api/ovrstat/ovrstat"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	c := newLRUCache(2)
+	expires := time.Now().Add(time.Minute)
+
+	c.set(ctx, "a", &cacheEntry{StaleUntil: expires})
+	c.set(ctx, "b", &cacheEntry{StaleUntil: expires})
+
+	// Touching a makes b the least recently used
+	if _, ok, _ := c.get(ctx, "a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	c.set(ctx, "c", &cacheEntry{StaleUntil: expires})
+
+	if _, ok, _ := c.get(ctx, "b"); ok {
+		t.Error("expected b to be evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.get(ctx, key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+}
+
+func TestCacheBackends(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{CacheMemory, Config{CacheBackend: CacheMemory, CacheSize: 10}},
+		{CacheDisk, Config{CacheBackend: CacheDisk, CachePath: filepath.Join(t.TempDir(), "cache.db")}},
+		{CacheRedis, Config{CacheBackend: CacheRedis, CacheURL: "redis://" + mr.Addr()}},
+	}
+
+	ctx := context.Background()
+
+	f, err := os.Open("../ovrstat/testdata/profile.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stats, err := ovrstat.ParseProfile(f, "pc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats.Name = "Viz"
+
+	for _, tt := range tests {
+		c, err := newCacheBackend(tt.cfg)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+
+		stale := time.Now().Add(time.Minute)
+		entry := &cacheEntry{
+			Stats:      stats,
+			StaleUntil: stale,
+		}
+
+		if err := c.set(ctx, "stats:pc:viz#1213", entry); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		c.set(ctx, "stats:pc:gone#1", &cacheEntry{NotFound: true, StaleUntil: time.Now().Add(-time.Second)})
+
+		got, ok, err := c.get(ctx, "stats:pc:viz#1213")
+		if err != nil || !ok || got.Stats == nil || got.Stats.Name != "Viz" || !got.StaleUntil.Equal(stale) {
+			t.Errorf("%s: unexpected entry %+v, %v, %v", tt.name, got, ok, err)
+		} else {
+			// Every backend must serve the same typed career stats, whatever
+			// it does to the untyped values
+			for hero, cs := range stats.CompetitiveStats.CareerStats {
+				if typed := got.Stats.CompetitiveStats.CareerStats[hero].Typed(); !reflect.DeepEqual(typed, cs.Typed()) {
+					t.Errorf("%s: expected the typed stats of %s to be %v, got %v", tt.name, hero, cs.Typed(), typed)
+				}
+			}
+		}
+
+		profile := &cacheEntry{
+			Profile:    &ovrstat.ProfileStats{Platforms: []string{"pc"}, Stats: map[string]*ovrstat.PlayerStats{"pc": stats}},
+			StaleUntil: stale,
+		}
+		if err := c.set(ctx, "profile::viz#1213", profile); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+
+		// Profiles keep the typed career stats of every platform
+		got, ok, err = c.get(ctx, "profile::viz#1213")
+		if err != nil || !ok || got.Profile == nil || got.Profile.Stats["pc"] == nil {
+			t.Errorf("%s: unexpected profile entry %+v, %v, %v", tt.name, got, ok, err)
+		} else if v, _ := got.Profile.Stats["pc"].QuickPlayStats.CareerStats["allHeroes"].Get("game", "timePlayed"); v.Display != "16:40:56" {
+			t.Errorf("%s: expected the typed stats of the profile, got %+v", tt.name, v)
+		}
+
+		for _, key := range []string{"stats:pc:gone#1", "stats:pc:nobody#1"} {
+			if _, ok, err := c.get(ctx, key); ok || err != nil {
+				t.Errorf("%s: expected %s to be missing, got %v", tt.name, key, err)
+			}
+		}
+
+		if err := c.close(); err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		}
+	}
+
+	// Entries of an older format are looked up again
+	old := []byte(`{"notFound":true,"staleUntil":"` + time.Now().Add(time.Minute).Format(time.RFC3339) + `"}`)
+	mr.Set(redisKeyPrefix+"stats:pc:old#1", string(old))
+
+	c, err := newCacheBackend(Config{CacheBackend: CacheRedis, CacheURL: "redis://" + mr.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.close()
+
+	if _, ok, err := c.get(ctx, "stats:pc:old#1"); ok || err != nil {
+		t.Errorf("expected an entry of an older format to be missing, got %v", err)
+	}
+
+	if _, err := newCacheBackend(Config{CacheBackend: "floppy"}); err == nil {
+		t.Error("expected an unknown backend to fail")
+	}
+}
+
+func TestBoltCacheSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	c, err := openBoltCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.set(ctx, "kept", &cacheEntry{NotFound: true, StaleUntil: time.Now().Add(time.Minute)})
+	c.set(ctx, "swept", &cacheEntry{NotFound: true, StaleUntil: time.Now().Add(-time.Second)})
+	c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(boltBucket)).Put([]byte("old"), []byte(`{"notFound":true,"staleUntil":"9999-01-01T00:00:00Z"}`))
+	})
+
+	if err := c.sweep(); err != nil {
+		t.Fatal(err)
+	}
+	c.close()
+
+	if c, err = openBoltCache(path); err != nil {
+		t.Fatal(err)
+	}
+	defer c.close()
+
+	if _, ok, _ := c.get(ctx, "kept"); !ok {
+		t.Error("expected the entry to survive reopening the cache")
+	}
+
+	c.db.View(func(tx *bolt.Tx) error {
+		if n := tx.Bucket([]byte(boltBucket)).Stats().KeyN; n != 1 {
+			t.Errorf("expected the sweep to leave 1 entry, got %d", n)
+		}
+		return nil
+	})
+}
+
+func TestStatsCache(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	tests := []struct {
+		path   string
+		status int
+		cache  string
+	}{
+		{"/stats/pc/Viz-1213", http.StatusOK, "MISS"},
+		{"/stats/pc/viz%231213", http.StatusOK, "HIT"},
+		{"/stats/PC/Viz-1213", http.StatusOK, "HIT"},
+		{"/stats/Console/Viz-1213", http.StatusOK, "MISS"},
+		{"/stats/console/Viz-1213", http.StatusOK, "HIT"},
+		{"/stats/xbox/Viz-1213", http.StatusBadRequest, ""},
+		{"/stats/pc/Nobody-1", http.StatusNotFound, "MISS"},
+		{"/stats/pc/nobody-1", http.StatusNotFound, "HIT"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status || rec.Header().Get(headerXCache) != tt.cache {
+			t.Errorf("%s: got %d %s, want %d %s", tt.path, rec.Code, rec.Header().Get(headerXCache), tt.status, tt.cache)
+		}
+	}
+
+	if n := up.profileRequests(); n != 2 {
+		t.Errorf("expected 2 profile requests, got %d", n)
+	}
+}
+
+func TestPlatformNotFoundCache(t *testing.T) {
+	up := newUpstream(t)
+
+	// Simulate a player who only ever played on pc
+	up.setPage(func(page string) string {
+		return strings.Replace(page, `<div class="Profile-player--filter" id="controllerFilter">Console</div>`, "", 1)
+	})
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	for _, cache := range []string{"MISS", "HIT"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/console/Viz-1213", nil))
+
+		if rec.Code != http.StatusNotFound || rec.Header().Get(headerXCache) != cache {
+			t.Errorf("got %d %s, want %d %s", rec.Code, rec.Header().Get(headerXCache), http.StatusNotFound, cache)
+		}
+	}
+
+	if n := up.profileRequests(); n != 1 {
+		t.Errorf("expected 1 profile request, got %d", n)
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+	cfg.CacheTTL = time.Nanosecond
+	cfg.StaleIfError = 0
+
+	e := EchoWithConfig(cfg)
+
+	for _, want := range []string{"MISS", "STALE"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/pc/Viz-1213", nil))
+
+		if rec.Code != http.StatusOK || rec.Header().Get(headerXCache) != want {
+			t.Fatalf("got %d %s, want 200 %s", rec.Code, rec.Header().Get(headerXCache), want)
+		}
+
+		if want == "STALE" && (rec.Header().Get(headerAge) == "" || rec.Header().Get("Last-Modified") == "") {
+			t.Error("expected the age of the stale response")
+		}
+	}
+
+	// The stale response triggered a refresh in the background
+	deadline := time.Now().Add(time.Second)
+	for up.profileRequests() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a background refresh, got %d profile requests", up.profileRequests())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStaleIfError(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+	cfg.CacheTTL = time.Nanosecond
+	cfg.StaleWhileRevalidate = 0
+
+	e := EchoWithConfig(cfg)
+
+	tests := []struct {
+		path    string
+		failing bool
+		status  int
+		cache   string
+	}{
+		{"/stats/pc/Viz-1213", false, http.StatusOK, "MISS"},
+		{"/stats/pc/Viz-1213", true, http.StatusOK, "STALE"},
+		{"/stats/console/Viz-1213", true, http.StatusServiceUnavailable, "MISS"},
+	}
+
+	for _, tt := range tests {
+		up.setFailing(tt.failing)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status || rec.Header().Get(headerXCache) != tt.cache {
+			t.Errorf("%s: got %d %s, want %d %s", tt.path, rec.Code, rec.Header().Get(headerXCache), tt.status, tt.cache)
+		}
+	}
+}
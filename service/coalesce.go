@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// flight is a lookup in progress whose result is shared by every request
+// waiting on it
+type flight struct {
+	done    chan struct{}
+	entry   *cacheEntry
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup collapses identical concurrent lookups into one upstream fetch
+type flightGroup struct {
+	mu        sync.Mutex
+	flights   map[string]*flight
+	fetches   uint64
+	coalesced uint64
+}
+
+// flightStats is the JSON form of the flightGroup counters
+type flightStats struct {
+	Fetches   uint64 `json:"fetches"`
+	Coalesced uint64 `json:"coalesced"`
+	InFlight  int    `json:"inFlight"`
+}
+
+// newFlightGroup creates and returns a new, empty flightGroup
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do performs the passed fetch for the passed key, or waits for and shares the
+// result of the one already in flight. The fetch isn't bound to the context of
+// any single caller, it's only cancelled once every caller has given up
+func (g *flightGroup) do(ctx context.Context, key string, fetch fetchFunc) (*cacheEntry, error) {
+	g.mu.Lock()
+	f, ok := g.flights[key]
+	if ok {
+		g.coalesced++
+	} else {
+		g.fetches++
+
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+
+		go g.run(fctx, key, f, fetch)
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.entry, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody wants the result anymore, later callers start afresh
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+// run performs the fetch of a flight and hands its result to the waiters
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fetch fetchFunc) {
+	defer f.cancel()
+
+	f.entry, f.err = fetch(ctx)
+
+	g.mu.Lock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	g.mu.Unlock()
+
+	close(f.done)
+}
+
+// stats returns the counters of the group
+func (g *flightGroup) stats() flightStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return flightStats{
+		Fetches:   g.fetches,
+		Coalesced: g.coalesced,
+		InFlight:  len(g.flights),
+	}
+}
+
+// debugLookups serves how many upstream fetches were performed and how many
+// requests were coalesced into one already in flight
+func (s *server) debugLookups(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.flights.stats())
+}
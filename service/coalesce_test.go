@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlightGroup(t *testing.T) {
+	g := newFlightGroup()
+	release := make(chan struct{})
+
+	var mu sync.Mutex
+	fetches := 0
+
+	fetch := func(ctx context.Context) (*cacheEntry, error) {
+		mu.Lock()
+		fetches++
+		mu.Unlock()
+
+		select {
+		case <-release:
+			return &cacheEntry{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// The first caller gives up, which mustn't cancel the fetch the others
+	// are waiting on
+	ctx, cancel := context.WithCancel(context.Background())
+
+	const n = 10
+	results := make(chan error, n)
+	for i := 0; i < n; i++ {
+		c := context.Background()
+		if i == 0 {
+			c = ctx
+		}
+
+		go func() {
+			_, err := g.do(c, "stats:pc:viz#1213", fetch)
+			results <- err
+		}()
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for g.stats().Coalesced != n-1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d coalesced lookups, got %+v", n-1, g.stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	if err := <-results; err != context.Canceled {
+		t.Fatalf("expected the abandoned lookup to be cancelled, got %v", err)
+	}
+	close(release)
+
+	for i := 1; i < n; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("expected the shared result, got %v", err)
+		}
+	}
+
+	if stats := g.stats(); fetches != 1 || stats.Fetches != 1 || stats.InFlight != 0 {
+		t.Errorf("expected a single completed fetch, got %d fetches and %+v", fetches, stats)
+	}
+}
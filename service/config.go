@@ -0,0 +1,137 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ow-api/ovrstat/ovrstat"
+	"github.com/pkg/errors"
+)
+
+// Config configures the service
+type Config struct {
+	// Port is the port the server listens on
+	Port string
+
+	// GRPCPort is the port the gRPC API listens on, disabled when empty. It's
+	// only served by StartWithConfig
+	GRPCPort string
+
+	// Client performs the lookups. When nil a client limited to the upstream
+	// rate below is created, or ovrstat.DefaultClient is used when that's 0
+	Client *ovrstat.Client
+
+	// UpstreamRate is how many requests per second are made to Blizzard on
+	// average, with bursts of up to UpstreamBurst. 0 disables the limit
+	UpstreamRate  float64
+	UpstreamBurst int
+
+	// UpstreamQueueTimeout is how long a request over the upstream rate may
+	// wait for its turn before the lookup fails with a 503
+	UpstreamQueueTimeout time.Duration
+
+	// APIKeysFile is a JSON file listing the API keys clients may send in the
+	// X-API-Key header, none when empty
+	APIKeysFile string
+
+	// TrustedProxies lists the addresses or CIDR ranges of the reverse proxies
+	// in front of the service, whose X-Forwarded-For header is trusted to tell
+	// the IP of anonymous clients. When empty the IP the request came from is
+	// used and the header is ignored
+	TrustedProxies []string
+
+	// AnonymousRate is how many API requests per second a client without an
+	// API key may make per IP on average, with bursts of up to AnonymousBurst.
+	// 0 disables the limit
+	AnonymousRate  float64
+	AnonymousBurst int
+
+	// KeyRate is how many API requests per second a client may make per API
+	// key on average, with bursts of up to KeyBurst, unless the key sets its
+	// own limit. 0 disables the limit
+	KeyRate  float64
+	KeyBurst int
+
+	// CacheBackend selects where lookups are cached, one of CacheMemory (the
+	// default), CacheDisk or CacheRedis
+	CacheBackend string
+
+	// CachePath is the database file of the CacheDisk backend
+	CachePath string
+
+	// CacheURL is the Redis URL of the CacheRedis backend, such as
+	// "redis://localhost:6379/0"
+	CacheURL string
+
+	// CacheSize is the maximum number of lookups cached in memory, 0 disables
+	// caching. It doesn't bound the other backends
+	CacheSize int
+
+	// CacheTTL is how long found stats are served from the cache
+	CacheTTL time.Duration
+
+	// NotFoundTTL is how long players that weren't found, or have no stats on
+	// the platform, are cached for. 0 disables caching them
+	NotFoundTTL time.Duration
+
+	// PrivateTTL is how long private profiles are cached for, 0 disables
+	// caching them
+	PrivateTTL time.Duration
+
+	// StaleWhileRevalidate is how long after expiring a cached lookup is still
+	// served while it's refreshed in the background
+	StaleWhileRevalidate time.Duration
+
+	// StaleIfError is how long after expiring a cached lookup is still served
+	// when refreshing it fails upstream
+	StaleIfError time.Duration
+}
+
+// DefaultConfig is the configuration used by Start and Echo
+var DefaultConfig = Config{
+	Port:     "8080",
+	GRPCPort: "9090",
+
+	UpstreamRate:         5,
+	UpstreamBurst:        10,
+	UpstreamQueueTimeout: 5 * time.Second,
+
+	AnonymousRate:  0.5,
+	AnonymousBurst: 10,
+	KeyRate:        5,
+	KeyBurst:       50,
+
+	CacheBackend: CacheMemory,
+	CachePath:    "ovrstat.db",
+	CacheSize:    10000,
+	CacheTTL:     10 * time.Minute,
+	NotFoundTTL:  time.Minute,
+	PrivateTTL:   2 * time.Minute,
+
+	StaleWhileRevalidate: time.Hour,
+	StaleIfError:         6 * time.Hour,
+}
+
+// validate checks the config for limits that can't be enforced, such as a rate
+// with no burst which would reject every request
+func (cfg Config) validate() error {
+	limits := []struct {
+		name  string
+		rate  float64
+		burst int
+	}{
+		{"upstream", cfg.UpstreamRate, cfg.UpstreamBurst},
+		{"anonymous", cfg.AnonymousRate, cfg.AnonymousBurst},
+		{"key", cfg.KeyRate, cfg.KeyBurst},
+	}
+
+	for _, l := range limits {
+		if l.rate < 0 {
+			return errors.Errorf("The %s rate can't be negative", l.name)
+		}
+		if l.rate > 0 && l.burst <= 0 {
+			return errors.Errorf("The %s burst must be at least 1 when its rate is set", l.name)
+		}
+	}
+
+	return nil
+}
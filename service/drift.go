@@ -0,0 +1,126 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+// driftMonitor counts the schema drift reported by every scraped profile so
+// changes to the Blizzard career pages are noticed before users report them
+type driftMonitor struct {
+	mu      sync.Mutex
+	parsed  int64
+	drifted int64
+	counts  map[ovrstat.Drift]*driftCount
+}
+
+// driftCount holds how often and when a single drift has been seen
+type driftCount struct {
+	ovrstat.Drift
+	Count     int64     `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// driftReport is the response body of the drift debug endpoint
+type driftReport struct {
+	Parsed  int64        `json:"parsed"`
+	Drifted int64        `json:"drifted"`
+	Drift   []driftCount `json:"drift"`
+}
+
+// newDriftMonitor creates and returns a new, empty driftMonitor
+func newDriftMonitor() *driftMonitor {
+	return &driftMonitor{counts: make(map[ovrstat.Drift]*driftCount)}
+}
+
+// record counts the drift of a single parsed profile, returning any drift that
+// has never been seen before
+func (m *driftMonitor) record(drift []ovrstat.Drift) []ovrstat.Drift {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.parsed++
+
+	if len(drift) == 0 {
+		return nil
+	}
+
+	m.drifted++
+
+	var fresh []ovrstat.Drift
+	now := time.Now()
+
+	for _, d := range drift {
+		dc, ok := m.counts[d]
+
+		if !ok {
+			dc = &driftCount{Drift: d, FirstSeen: now}
+			m.counts[d] = dc
+			fresh = append(fresh, d)
+		}
+
+		dc.Count++
+		dc.LastSeen = now
+	}
+
+	return fresh
+}
+
+// report returns a snapshot of the drift seen so far, most frequent first
+func (m *driftMonitor) report() driftReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := driftReport{
+		Parsed:  m.parsed,
+		Drifted: m.drifted,
+		Drift:   make([]driftCount, 0, len(m.counts)),
+	}
+
+	for _, dc := range m.counts {
+		r.Drift = append(r.Drift, *dc)
+	}
+
+	sort.Slice(r.Drift, func(i, j int) bool {
+		return r.Drift[i].Count > r.Drift[j].Count
+	})
+
+	return r
+}
+
+// recordDrift counts the drift of a freshly scraped career page, logging any
+// that hasn't been seen before
+func (s *server) recordDrift(drift []ovrstat.Drift) {
+	for _, d := range s.drift.record(drift) {
+		s.logger.Warnf("Career page drift: %s %s %s", d.Kind, d.Selector, d.Name)
+	}
+}
+
+// debugDrift serves the drift seen by the service as JSON
+func (s *server) debugDrift(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.drift.report())
+}
+
+// profileDrift returns the drift of every platform of a profile, each only
+// once as they're all parsed from the same career page
+func profileDrift(profile *ovrstat.ProfileStats) []ovrstat.Drift {
+	var drift []ovrstat.Drift
+	seen := make(map[ovrstat.Drift]bool)
+
+	for _, platform := range profile.Platforms {
+		for _, d := range profile.Stats[platform].Drift {
+			if !seen[d] {
+				seen[d] = true
+				drift = append(drift, d)
+			}
+		}
+	}
+
+	return drift
+}
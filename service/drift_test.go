@@ -0,0 +1,64 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+func TestDebugDrift(t *testing.T) {
+	up := newUpstream(t)
+
+	// Rename a category so every scrape reports it as unknown
+	up.setPage(func(page string) string {
+		return strings.Replace(page, `<p>Match Awards</p>`, `<p>Match Honors</p>`, -1)
+	})
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	// Both platforms of the first lookup come from a single page
+	for _, path := range []string{"/stats/Viz-1213", "/stats/pc/Viz-1213"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", path, rec.Code)
+		}
+	}
+
+	if n := up.profileRequests(); n != 2 {
+		t.Fatalf("expected 2 career page fetches, got %d", n)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/drift", nil))
+
+	var report driftReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Parsed != 2 || report.Drifted != 2 {
+		t.Errorf("expected 2 parsed and drifted pages, got %d and %d", report.Parsed, report.Drifted)
+	}
+
+	want := ovrstat.Drift{Kind: ovrstat.DriftUnknownCategory, Selector: "div.category .header p", Name: "matchHonors"}
+
+	for _, dc := range report.Drift {
+		if dc.Drift == want {
+			if dc.Count != 2 {
+				t.Errorf("expected the unknown category to be counted twice, got %d", dc.Count)
+			}
+			return
+		}
+	}
+
+	t.Errorf("expected the unknown category in the drift, got %+v", report.Drift)
+}
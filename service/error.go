@@ -1,17 +1,178 @@
 package service
 
-import "github.com/labstack/echo/v4"
-
-// newErr creates and returns a new echo HTTPError with the passed status code
-// and optional message. Message expected to be of type string or error
-func newErr(code int, message ...interface{}) error {
-	if len(message) > 0 {
-		switch v := message[0].(type) {
-		case error:
-			return echo.NewHTTPError(code, v.Error())
-		case string:
-			return echo.NewHTTPError(code, v)
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+	"github.com/pkg/errors"
+)
+
+const (
+	// problemTypeBase is the URI every problem type is named relative to
+	problemTypeBase = "https://ovrstat.com/problems/"
+
+	// mimeProblemJSON is the content type of every error response
+	mimeProblemJSON = "application/problem+json"
+)
+
+// problem is an RFC 7807 problem details error response. Code is a stable,
+// machine readable identifier clients can branch on
+type problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId,omitempty"`
+
+	// internal is the error that caused the problem. It's logged but never
+	// served, so internal error chains don't leak to clients
+	internal error
+}
+
+// Error implements the error interface
+func (p *problem) Error() string {
+	if p.internal != nil {
+		return p.Code + ": " + p.Detail + ": " + p.internal.Error()
+	}
+	return p.Code + ": " + p.Detail
+}
+
+// newErr creates and returns a new problem with the passed status code and
+// optional detail message. Message expected to be of type string
+func newErr(status int, detail ...string) *problem {
+	return newProblem(status, statusCode(status), detail...)
+}
+
+// newProblem creates and returns a new problem with the passed status code,
+// error code and optional detail message
+func newProblem(status int, code string, detail ...string) *problem {
+	p := &problem{
+		Type:   problemTypeBase + strings.Replace(code, "_", "-", -1),
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+	}
+	if len(detail) > 0 {
+		p.Detail = detail[0]
+	}
+	return p
+}
+
+// withInternal sets the error that caused the problem, to be logged
+func (p *problem) withInternal(err error) *problem {
+	p.internal = err
+	return p
+}
+
+// statusCode returns the default error code of an HTTP status, such as
+// "internal_server_error"
+func statusCode(status int) string {
+	return strings.ToLower(strings.Replace(http.StatusText(status), " ", "_", -1))
+}
+
+// errorHandler is the echo HTTPErrorHandler serving every error returned by a
+// handler or middleware as a problem, logging internal errors along the way
+func errorHandler(err error, c echo.Context) {
+	var p *problem
+
+	if !errors.As(err, &p) {
+		var he *echo.HTTPError
+
+		if errors.As(err, &he) {
+			p = newErr(he.Code)
+			if msg, ok := he.Message.(string); ok {
+				p.Detail = msg
+			}
+			p.internal = he.Internal
+		} else {
+			p = newErr(http.StatusInternalServerError, "An error has occurred").withInternal(err)
 		}
 	}
-	return echo.NewHTTPError(code, "An error has occurred")
+
+	p.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+
+	if p.internal != nil || p.Status >= http.StatusInternalServerError {
+		c.Logger().Errorj(map[string]interface{}{
+			"id":     p.RequestID,
+			"status": p.Status,
+			"code":   p.Code,
+			"error":  p.Error(),
+		})
+	}
+
+	if c.Response().Committed {
+		return
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(p.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, mimeProblemJSON)
+		err = c.JSON(p.Status, p)
+	}
+
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// lookupErr maps an error returned by an ovrstat lookup to the problem served
+// to the client, setting the Retry-After header when upstream asked to wait
+func lookupErr(c echo.Context, err error) error {
+	setRetryAfter(c, err)
+	return lookupProblem(err)
+}
+
+// lookupProblem maps an error returned by an ovrstat lookup to its problem
+func lookupProblem(err error) *problem {
+	switch {
+	case errors.Is(err, ovrstat.ErrPlayerNotFound):
+		return newProblem(http.StatusNotFound, "player_not_found", "Player not found")
+	case errors.Is(err, ovrstat.ErrPlayerPrivate):
+		return newProblem(http.StatusForbidden, "player_private", "Player profile is private")
+	case errors.Is(err, ovrstat.ErrPlatformNotFound):
+		return newProblem(http.StatusNotFound, "platform_not_found", "Player has no stats on this platform")
+	case errors.Is(err, ovrstat.ErrInvalidPlatform):
+		return newProblem(http.StatusBadRequest, "invalid_platform", "Invalid platform")
+	case errors.Is(err, ovrstat.ErrInvalidBattleTag):
+		return newProblem(http.StatusBadRequest, "invalid_battletag", "Invalid battletag")
+	case errors.Is(err, ovrstat.ErrAmbiguousPlayer):
+		return newProblem(http.StatusConflict, "ambiguous_player", "Multiple players match, use a full battletag")
+	case errors.Is(err, ovrstat.ErrUpstreamRateLimited):
+		return newProblem(http.StatusTooManyRequests, "upstream_rate_limited", "Blizzard is rate limiting requests, try again later").withInternal(err)
+	case errors.Is(err, ovrstat.ErrQueueFull):
+		return newProblem(http.StatusServiceUnavailable, "upstream_queue_full", "Too many lookups are queued, try again later").withInternal(err)
+	case errors.Is(err, ovrstat.ErrTimeout):
+		return newProblem(http.StatusGatewayTimeout, "upstream_timeout", "Blizzard took too long to respond").withInternal(err)
+	case errors.Is(err, ovrstat.ErrUpstreamMaintenance):
+		return newProblem(http.StatusServiceUnavailable, "upstream_maintenance", "Blizzard is down for maintenance, try again later").withInternal(err)
+	case errors.Is(err, ovrstat.ErrUpstreamUnavailable):
+		return newProblem(http.StatusServiceUnavailable, "upstream_unavailable", "Blizzard is unavailable, try again later").withInternal(err)
+	case errors.Is(err, ovrstat.ErrMarkupChanged):
+		return newProblem(http.StatusBadGateway, "markup_changed", "Blizzard returned a response that couldn't be parsed").withInternal(err)
+	}
+	return newErr(http.StatusInternalServerError, "Failed to retrieve player stats").withInternal(err)
+}
+
+// setRetryAfter sets the Retry-After header to the whole number of seconds an
+// upstream error asks to wait for, rounded up
+func setRetryAfter(c echo.Context, err error) {
+	if d := retryAfter(err); d > 0 {
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(d)))
+	}
+}
+
+// retryAfter returns how long an upstream error asks to wait for, 0 when it
+// doesn't
+func retryAfter(err error) time.Duration {
+	var upstream *ovrstat.UpstreamError
+	if errors.As(err, &upstream) {
+		return upstream.RetryAfter
+	}
+	return 0
 }
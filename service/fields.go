@@ -0,0 +1,313 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+const (
+	// fieldWildcard matches every key of a map, such as every hero in
+	// "quickPlayStats.topHeroes.*.timePlayed"
+	fieldWildcard = "*"
+
+	// Fields of PlayerStats the hero and category filters apply to
+	fieldTopHeroes   = "topHeroes"
+	fieldCareerStats = "careerStats"
+
+	// fieldValues is the field the typed stats of every category are served
+	// under in a hero's career stats, when asked for with the typed query
+	// parameter
+	fieldValues = "values"
+)
+
+// modeFields are the fields of PlayerStats holding the stats of a play mode
+var modeFields = []string{"quickPlayStats", "competitiveStats"}
+
+// selection is the part of the stats a client asked for with the fields,
+// heroes and categories query parameters, and whether the typed career stats
+// were asked for with the typed query parameter
+type selection struct {
+	fields     fieldTree
+	heroes     map[string]bool
+	categories map[string]bool
+	typed      bool
+}
+
+// fieldTree is a set of dot paths split into a tree by segment. A nil tree
+// selects everything below it
+type fieldTree map[string]fieldTree
+
+// parseSelection parses the selection query parameters of a request, all comma
+// separated lists. Fields are validated against PlayerStats and categories
+// against CareerStats. It returns nil when the whole stats were asked for
+func parseSelection(c echo.Context) (*selection, error) {
+	fields := queryList(c, "fields")
+	heroes := queryList(c, "heroes")
+	categories := queryList(c, "categories")
+
+	var typed bool
+	if v := c.QueryParam("typed"); v != "" {
+		var err error
+		if typed, err = strconv.ParseBool(v); err != nil {
+			return nil, newProblem(http.StatusBadRequest, "invalid_typed", "typed must be true or false")
+		}
+	}
+
+	if len(fields) == 0 && len(heroes) == 0 && len(categories) == 0 && !typed {
+		return nil, nil
+	}
+
+	sel := &selection{typed: typed}
+
+	for _, f := range fields {
+		path := strings.Split(f, ".")
+		if !validField(reflect.TypeOf(ovrstat.PlayerStats{}), path) {
+			return nil, newProblem(http.StatusBadRequest, "invalid_fields", "Unknown field "+f)
+		}
+
+		// Selecting the typed stats asks for them
+		if len(path) > 3 && path[1] == fieldCareerStats && path[3] == fieldValues {
+			sel.typed = true
+		}
+
+		if sel.fields == nil {
+			sel.fields = make(fieldTree)
+		}
+		sel.fields.add(path)
+	}
+
+	if len(heroes) > 0 {
+		sel.heroes = make(map[string]bool)
+		for _, h := range heroes {
+			sel.heroes[strings.ToLower(h)] = true
+		}
+	}
+
+	if len(categories) > 0 {
+		sel.categories = make(map[string]bool)
+		for _, cat := range categories {
+			if _, ok := jsonField(reflect.TypeOf(ovrstat.CareerStats{}), cat); !ok {
+				return nil, newProblem(http.StatusBadRequest, "invalid_categories", "Unknown career stats category "+cat)
+			}
+			sel.categories[cat] = true
+		}
+	}
+
+	return sel, nil
+}
+
+// queryList returns the comma separated values of a query parameter, which may
+// also be repeated
+func queryList(c echo.Context, name string) []string {
+	var list []string
+	for _, v := range c.QueryParams()[name] {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// add adds the passed path to the tree. Paths below one that's already
+// selected as a whole are ignored
+func (t fieldTree) add(path []string) {
+	for i, seg := range path {
+		sub, ok := t[seg]
+		if ok && sub == nil {
+			return
+		}
+
+		if i == len(path)-1 {
+			t[seg] = nil
+			return
+		}
+
+		if !ok {
+			sub = make(fieldTree)
+			t[seg] = sub
+		}
+		t = sub
+	}
+}
+
+// project returns the parts of the passed decoded JSON value the tree selects.
+// Trees apply to every element of an array
+func (t fieldTree) project(v interface{}) interface{} {
+	if t == nil {
+		return v
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{})
+		for k, child := range v {
+			sub, ok := t[k]
+			if !ok {
+				sub, ok = t[fieldWildcard]
+			}
+			if ok {
+				out[k] = sub.project(child)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = t.project(child)
+		}
+		return out
+	}
+	return v
+}
+
+// validField reports whether the passed path exists in the JSON form of the
+// passed type. Any key, or the wildcard, is valid for a map
+func validField(t reflect.Type, path []string) bool {
+	for _, seg := range path {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+
+		// The typed stats are served under values when asked for
+		if t == reflect.TypeOf(ovrstat.CareerStats{}) && seg == fieldValues {
+			t = reflect.TypeOf(ovrstat.StatValues{})
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			f, ok := jsonField(t, seg)
+			if !ok {
+				return false
+			}
+			t = f.Type
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Interface:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// jsonField returns the field of a struct type encoded under the passed JSON
+// name, looking into embedded structs like encoding/json does
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			if ef, ok := jsonField(f.Type, name); ok {
+				return ef, true
+			}
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if tag == "" {
+			tag = f.Name
+		}
+		if tag == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// apply returns the selected part of the passed stats in decoded JSON form.
+// The stats themselves are shared with the cache and left untouched
+func (sel *selection) apply(stats *ovrstat.PlayerStats) (interface{}, error) {
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var tree map[string]interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	collections := statsCollections(stats)
+
+	for _, mode := range modeFields {
+		m, _ := tree[mode].(map[string]interface{})
+
+		if sel.typed {
+			heroes, _ := m[fieldCareerStats].(map[string]interface{})
+			for hero, cs := range collections[mode].CareerStats {
+				if categories, ok := heroes[hero].(map[string]interface{}); ok {
+					categories[fieldValues] = typedValues(cs)
+				}
+			}
+		}
+
+		if sel.heroes != nil {
+			for _, section := range []string{fieldTopHeroes, fieldCareerStats} {
+				heroes, _ := m[section].(map[string]interface{})
+				for hero := range heroes {
+					if !sel.heroes[strings.ToLower(hero)] {
+						delete(heroes, hero)
+					}
+				}
+			}
+		}
+
+		if sel.categories != nil {
+			heroes, _ := m[fieldCareerStats].(map[string]interface{})
+			for _, hero := range heroes {
+				categories, _ := hero.(map[string]interface{})
+				for cat := range categories {
+					if !sel.categories[cat] && cat != fieldValues {
+						delete(categories, cat)
+					}
+				}
+
+				// The typed stats are filtered the same way
+				values, _ := categories[fieldValues].(map[string]interface{})
+				for cat := range values {
+					if !sel.categories[cat] {
+						delete(values, cat)
+					}
+				}
+			}
+		}
+	}
+
+	return sel.fields.project(tree), nil
+}
+
+// typedValues returns the typed stats of the passed career stats by category
+// and key, in the decoded JSON form the selection is applied to
+func typedValues(cs *ovrstat.CareerStats) map[string]interface{} {
+	values := make(map[string]interface{})
+	for category, stats := range cs.Typed() {
+		typed := make(map[string]interface{}, len(stats))
+		for key, v := range stats {
+			typed[key] = v
+		}
+		values[category] = typed
+	}
+	return values
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFieldSelection(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+	cfg.AnonymousRate = 0
+
+	e := EchoWithConfig(cfg)
+
+	tests := []struct {
+		query  string
+		status int
+		want   string
+	}{
+		{"fields=name,ratings.group", http.StatusOK,
+			`{"name":"Viz","ratings":[{"group":"Gold"},{"group":"Diamond"}]}`},
+		{"fields=competitiveStats.season,quickPlayStats.topHeroes.*.gamesWon&heroes=Ana", http.StatusOK,
+			`{"competitiveStats":{"season":9},"quickPlayStats":{"topHeroes":{"ana":{"gamesWon":152}}}}`},
+		{"fields=competitiveStats.careerStats&heroes=allHeroes&categories=best", http.StatusOK,
+			`{"competitiveStats":{"careerStats":{"allHeroes":{"best":{"eliminationsMostInGame":41,"killsStreakBest":19}}}}}`},
+		{"fields=competitiveStats.careerStats&heroes=allHeroes&categories=best&typed=true", http.StatusOK,
+			`{"competitiveStats":{"careerStats":{"allHeroes":{"best":{"eliminationsMostInGame":41,"killsStreakBest":19},` +
+				`"values":{"best":{"eliminationsMostInGame":{"kind":"count","value":41,"display":"41"},` +
+				`"killsStreakBest":{"kind":"count","value":19,"display":"19"}}}}}}}`},
+		{"fields=quickPlayStats.careerStats.allHeroes.values.game.timePlayed", http.StatusOK,
+			`{"quickPlayStats":{"careerStats":{"allHeroes":{"values":{"game":{"timePlayed":{"kind":"duration","value":60056,"display":"16:40:56"}}}}}}}`},
+		{"fields=nmae", http.StatusBadRequest, ""},
+		{"fields=name.first", http.StatusBadRequest, ""},
+		{"fields=drift", http.StatusBadRequest, ""},
+		{"categories=awards", http.StatusBadRequest, ""},
+		{"categories=values", http.StatusBadRequest, ""},
+		{"typed=maybe", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/pc/Viz-1213?"+tt.query, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d: %s", tt.query, tt.status, rec.Code, rec.Body)
+			continue
+		}
+
+		if tt.want == "" {
+			continue
+		}
+
+		var got, want interface{}
+		json.Unmarshal(rec.Body.Bytes(), &got)
+		json.Unmarshal([]byte(tt.want), &want)
+
+		if gb, _ := json.Marshal(got); string(gb) != mustJSON(want) {
+			t.Errorf("%s: got %s, want %s", tt.query, gb, tt.want)
+		}
+	}
+}
+
+// mustJSON returns the JSON encoding of the passed value, with sorted keys
+func mustJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
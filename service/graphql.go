@@ -0,0 +1,538 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+	"github.com/pkg/errors"
+)
+
+// maxGraphQLLookups bounds how many players a single GraphQL request may look
+// up or search for, aliases included. Each lookup costs the client a request
+// of its rate limit, the request itself paying for the first
+const maxGraphQLLookups = 10
+
+// graphqlRequest is a GraphQL request, sent as JSON in a POST body or as query
+// parameters of a GET
+type graphqlRequest struct {
+	Query         string                 `json:"query" query:"query"`
+	OperationName string                 `json:"operationName" query:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphqlContext is the per request state GraphQL resolvers share
+type graphqlContext struct {
+	echo    echo.Context
+	lookups int32
+
+	// client and tier identify the rate limit lookups are charged to
+	client string
+	tier   tier
+}
+
+// graphqlContextKey is the context key the graphqlContext is stored under
+type graphqlContextKey struct{}
+
+// playerResult is the outcome of a single lookup of the players query
+type playerResult struct {
+	Tag   string               `json:"tag"`
+	Stats *ovrstat.PlayerStats `json:"stats"`
+	Error *graphqlError        `json:"error"`
+}
+
+// heroEntry is a single hero of the stats maps keyed by hero
+type heroEntry struct {
+	Hero  string      `json:"hero"`
+	Stats interface{} `json:"stats"`
+}
+
+// statEntry is a single career stat in typed form
+type statEntry struct {
+	Key     string           `json:"key"`
+	Kind    ovrstat.StatKind `json:"kind"`
+	Value   float64          `json:"value"`
+	Display string           `json:"display"`
+}
+
+// graphqlError is a lookup error served to GraphQL clients, with the same
+// code and status as the problem the REST API would serve for it
+type graphqlError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+// Error implements the error interface
+func (e *graphqlError) Error() string {
+	return e.Message
+}
+
+// Extensions implements gqlerrors.ExtendedError, exposing the error code
+func (e *graphqlError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code, "status": e.Status}
+}
+
+// schemaBuilder builds GraphQL object types mirroring the ovrstat models from
+// their JSON form
+type schemaBuilder struct {
+	objects map[reflect.Type]*graphql.Object
+	entries map[reflect.Type]*graphql.Object
+	stat    *graphql.Object
+}
+
+// newGraphQLSchema builds the GraphQL schema of the service
+func (s *server) newGraphQLSchema() (graphql.Schema, error) {
+	b := &schemaBuilder{
+		objects: make(map[reflect.Type]*graphql.Object),
+		entries: make(map[reflect.Type]*graphql.Object),
+	}
+
+	playerStats := b.object(reflect.TypeOf(ovrstat.PlayerStats{}))
+
+	errorType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Error",
+		Description: "A failed lookup, coded like the errors of the REST API",
+		Fields: graphql.Fields{
+			"code":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"message": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	playerResult := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PlayerResult",
+		Description: "The stats of one of the players looked up, or why they couldn't be",
+		Fields: graphql.Fields{
+			"tag":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"stats": &graphql.Field{Type: playerStats},
+			"error": &graphql.Field{Type: errorType},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"player": &graphql.Field{
+				Type:        playerStats,
+				Description: "The stats of a player on a platform",
+				Args: graphql.FieldConfigArgument{
+					"platform": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"tag":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.resolvePlayer,
+			},
+			"players": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(playerResult))),
+				Description: "The stats of several players on a platform, looked up concurrently",
+				Args: graphql.FieldConfigArgument{
+					"platform": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"tags":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				},
+				Resolve: s.resolvePlayers,
+			},
+			"search": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(b.object(reflect.TypeOf(ovrstat.Player{}))))),
+				Description: "Every account matching a name",
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.resolveSearch,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// object returns the GraphQL object type of a model struct, named after it
+func (b *schemaBuilder) object(t reflect.Type) *graphql.Object {
+	if o, ok := b.objects[t]; ok {
+		return o
+	}
+
+	fields := graphql.Fields{}
+	o := graphql.NewObject(graphql.ObjectConfig{
+		Name: t.Name(),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return fields
+		}),
+	})
+	b.objects[t] = o
+
+	b.addFields(fields, t, nil)
+
+	return o
+}
+
+// addFields adds a field for every JSON field of the passed struct type,
+// flattening embedded structs like encoding/json does
+func (b *schemaBuilder) addFields(fields graphql.Fields, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		idx := append(append([]int(nil), index...), i)
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			b.addFields(fields, f.Type, idx)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields[name] = b.field(f.Type, name, idx)
+	}
+}
+
+// field returns the GraphQL field of a struct field. Maps keyed by hero become
+// lists of heroes, optionally filtered, and career stat categories lists of
+// typed stats
+func (b *schemaBuilder) field(t reflect.Type, name string, index []int) *graphql.Field {
+	if t.Kind() != reflect.Map {
+		return &graphql.Field{Type: b.output(t), Resolve: resolveField(index)}
+	}
+
+	if t.Elem().Kind() == reflect.Interface {
+		return &graphql.Field{
+			Type:    graphql.NewList(graphql.NewNonNull(b.statType())),
+			Resolve: resolveCategory(name, index),
+		}
+	}
+
+	return &graphql.Field{
+		Type: graphql.NewList(graphql.NewNonNull(b.entryType(t.Elem()))),
+		Args: graphql.FieldConfigArgument{
+			"heroes": &graphql.ArgumentConfig{
+				Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+				Description: "Only the listed heroes, matched regardless of case",
+			},
+		},
+		Resolve: resolveHeroes(index),
+	}
+}
+
+// output returns the GraphQL type of a model field type
+func (b *schemaBuilder) output(t reflect.Type) graphql.Output {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.output(t.Elem())
+	case reflect.Slice:
+		return graphql.NewList(b.output(t.Elem()))
+	case reflect.Struct:
+		return b.object(t)
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	}
+	return graphql.String
+}
+
+// entryType returns the type of a single hero of a map keyed by hero
+func (b *schemaBuilder) entryType(elem reflect.Type) *graphql.Object {
+	if o, ok := b.entries[elem]; ok {
+		return o
+	}
+
+	o := graphql.NewObject(graphql.ObjectConfig{
+		Name: b.output(elem).Name() + "Entry",
+		Fields: graphql.Fields{
+			"hero":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"stats": &graphql.Field{Type: b.output(elem)},
+		},
+	})
+	b.entries[elem] = o
+
+	return o
+}
+
+// statType returns the type of a single typed career stat
+func (b *schemaBuilder) statType() *graphql.Object {
+	if b.stat == nil {
+		b.stat = graphql.NewObject(graphql.ObjectConfig{
+			Name: "Stat",
+			Fields: graphql.Fields{
+				"key":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"kind":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"value":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+				"display": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			},
+		})
+	}
+	return b.stat
+}
+
+// resolveField resolves a struct field by its index, handing nested structs on
+// by pointer
+func resolveField(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+
+		f := v.FieldByIndex(index)
+		switch {
+		case f.Kind() == reflect.Ptr && f.IsNil():
+			return nil, nil
+		case f.Kind() == reflect.Struct && f.CanAddr():
+			return f.Addr().Interface(), nil
+		}
+		return f.Interface(), nil
+	}
+}
+
+// resolveHeroes resolves a map keyed by hero into a sorted list of heroes
+func resolveHeroes(index []int) graphql.FieldResolveFn {
+	field := resolveField(index)
+
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		m, err := field(p)
+		if err != nil || m == nil {
+			return nil, err
+		}
+
+		var only map[string]bool
+		if heroes, ok := p.Args["heroes"].([]interface{}); ok {
+			only = make(map[string]bool)
+			for _, h := range heroes {
+				only[strings.ToLower(h.(string))] = true
+			}
+		}
+
+		mv := reflect.ValueOf(m)
+
+		var entries []heroEntry
+		for _, k := range mv.MapKeys() {
+			if hero := k.String(); only == nil || only[strings.ToLower(hero)] {
+				entries = append(entries, heroEntry{Hero: hero, Stats: mv.MapIndex(k).Interface()})
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Hero < entries[j].Hero })
+
+		return entries, nil
+	}
+}
+
+// resolveCategory resolves a career stats category into a sorted list of
+// typed stats
+func resolveCategory(category string, index []int) graphql.FieldResolveFn {
+	field := resolveField(index)
+
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		cs, ok := p.Source.(*ovrstat.CareerStats)
+		if !ok || cs == nil {
+			return nil, nil
+		}
+
+		m, err := field(p)
+		if err != nil {
+			return nil, err
+		}
+		values, _ := m.(map[string]interface{})
+		if values == nil {
+			return nil, nil
+		}
+
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		stats := make([]statEntry, 0, len(keys))
+		for _, k := range keys {
+			v, _ := cs.Get(category, k)
+			stats = append(stats, statEntry{Key: k, Kind: v.Kind, Value: v.Value, Display: v.Display})
+		}
+		return stats, nil
+	}
+}
+
+// resolvePlayer looks up the stats of a player, concurrently with any other
+// lookup of the request
+func (s *server) resolvePlayer(p graphql.ResolveParams) (interface{}, error) {
+	platform, _ := p.Args["platform"].(string)
+	tag, _ := p.Args["tag"].(string)
+
+	wait := s.graphqlLookups(p.Context, platform, []string{tag})
+
+	return func() (interface{}, error) {
+		res := wait()[0]
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		return res.Stats, nil
+	}, nil
+}
+
+// resolvePlayers looks up the stats of several players concurrently
+func (s *server) resolvePlayers(p graphql.ResolveParams) (interface{}, error) {
+	platform, _ := p.Args["platform"].(string)
+
+	var tags []string
+	for _, t := range p.Args["tags"].([]interface{}) {
+		tags = append(tags, t.(string))
+	}
+
+	wait := s.graphqlLookups(p.Context, platform, tags)
+
+	return func() (interface{}, error) {
+		return wait(), nil
+	}, nil
+}
+
+// resolveSearch lists every account matching a name
+func (s *server) resolveSearch(p graphql.ResolveParams) (interface{}, error) {
+	name, _ := p.Args["name"].(string)
+
+	// Searches aren't cached, so they count as lookups like players do
+	gc := p.Context.Value(graphqlContextKey{}).(*graphqlContext)
+	if err := s.startGraphQLLookup(gc); err != nil {
+		return nil, err
+	}
+
+	players, err := s.client.SearchContext(p.Context, name)
+	if err != nil {
+		return nil, s.graphqlErr(p.Context, err)
+	}
+	return players, nil
+}
+
+// graphqlLookups starts looking up the stats of the passed players through
+// the cache, returning a function waiting for their results
+func (s *server) graphqlLookups(ctx context.Context, platform string, tags []string) func() []playerResult {
+	gc := ctx.Value(graphqlContextKey{}).(*graphqlContext)
+
+	results := make([]playerResult, len(tags))
+	errs := make([]error, len(tags))
+
+	var wg sync.WaitGroup
+	for i, tag := range tags {
+		results[i].Tag = tag
+
+		if results[i].Error = s.startGraphQLLookup(gc); results[i].Error != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, tag string) {
+			defer wg.Done()
+			results[i].Stats, errs[i] = s.lookupStatsContext(ctx, platform, tag)
+		}(i, tag)
+	}
+
+	return func() []playerResult {
+		wg.Wait()
+
+		// Errors are mapped once every lookup is done, as mapping them may
+		// set response headers
+		for i, err := range errs {
+			if err != nil {
+				results[i].Error = s.graphqlErr(ctx, err)
+			}
+		}
+		return results
+	}
+}
+
+// startGraphQLLookup counts a player lookup or search of a request, returning
+// the error to serve for it when the request made too many already or its
+// client is over the rate limit. Every lookup past the first is taken from the
+// rate limit, the request itself paying for the first
+func (s *server) startGraphQLLookup(gc *graphqlContext) *graphqlError {
+	n := atomic.AddInt32(&gc.lookups, 1)
+	if n > maxGraphQLLookups {
+		return &graphqlError{
+			Code:    "too_many_lookups",
+			Message: fmt.Sprintf("A single request may make at most %d lookups", maxGraphQLLookups),
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	if n == 1 || gc.tier.rate <= 0 {
+		return nil
+	}
+
+	res := s.limits.take(gc.client, gc.tier, time.Now())
+	setRateLimitHeaders(gc.echo.Response().Header(), gc.tier, res)
+
+	if !res.allowed {
+		return &graphqlError{
+			Code:    "rate_limited",
+			Message: "Too many requests, try again later",
+			Status:  http.StatusTooManyRequests,
+		}
+	}
+	return nil
+}
+
+// graphqlErr maps a lookup error to the problem the REST API would serve for
+// it, logging internal errors like the error handler does
+func (s *server) graphqlErr(ctx context.Context, err error) *graphqlError {
+	gc := ctx.Value(graphqlContextKey{}).(*graphqlContext)
+
+	var p *problem
+	if !errors.As(lookupErr(gc.echo, err), &p) {
+		p = newErr(http.StatusInternalServerError, "An error has occurred")
+	}
+
+	if p.internal != nil || p.Status >= http.StatusInternalServerError {
+		s.logger.Warnf("GraphQL lookup failed: %s", p)
+	}
+
+	return &graphqlError{Code: p.Code, Message: p.Detail, Status: p.Status}
+}
+
+// graphql serves GraphQL queries over the player stats
+func (s *server) graphql(c echo.Context) error {
+	var req graphqlRequest
+	if err := c.Bind(&req); err != nil {
+		return newProblem(http.StatusBadRequest, "invalid_graphql_request", "The GraphQL request couldn't be decoded")
+	}
+
+	if req.Query == "" {
+		return newProblem(http.StatusBadRequest, "invalid_graphql_request", "The GraphQL request has no query")
+	}
+
+	id, t, err := s.limits.identify(c)
+	if err != nil {
+		return err
+	}
+
+	gc := &graphqlContext{echo: c, client: id, tier: t}
+	ctx := context.WithValue(c.Request().Context(), graphqlContextKey{}, gc)
+
+	res := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        ctx,
+	})
+
+	return c.JSON(http.StatusOK, res)
+}
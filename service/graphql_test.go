@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGraphQL(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	query := `{
+		viz: player(platform: "pc", tag: "Viz-1213") {
+			name
+			ratings { role group tier }
+			competitiveStats {
+				season
+				careerStats(heroes: ["allHeroes"]) { hero stats { best { key kind value } } }
+			}
+		}
+		players(platform: "pc", tags: ["viz#1213", "Nobody-1"]) {
+			tag
+			stats { name quickPlayStats { topHeroes(heroes: ["ANA"]) { hero stats { timePlayedSeconds } } } }
+			error { code status }
+		}
+		search(name: "Viz") { battleTag isPublic }
+	}`
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var res struct {
+		Data struct {
+			Viz struct {
+				Name             string
+				Ratings          []struct{ Role, Group string }
+				CompetitiveStats struct {
+					Season      int
+					CareerStats []struct {
+						Hero  string
+						Stats struct {
+							Best []struct {
+								Key, Kind string
+								Value     float64
+							}
+						}
+					}
+				}
+			}
+			Players []struct {
+				Tag   string
+				Stats *struct {
+					Name           string
+					QuickPlayStats struct {
+						TopHeroes []struct{ Hero string }
+					}
+				}
+				Error *struct {
+					Code   string
+					Status int
+				}
+			}
+			Search []struct{ BattleTag string }
+		}
+		Errors []interface{}
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Errors) != 0 {
+		t.Fatalf("unexpected errors %v", res.Errors)
+	}
+
+	viz := res.Data.Viz
+	if viz.Name == "" || len(viz.Ratings) != 2 || viz.CompetitiveStats.Season != 9 {
+		t.Errorf("unexpected player %+v", viz)
+	}
+
+	if cs := viz.CompetitiveStats.CareerStats; len(cs) != 1 || cs[0].Hero != "allHeroes" || len(cs[0].Stats.Best) != 2 ||
+		cs[0].Stats.Best[0].Kind != "count" || cs[0].Stats.Best[0].Value != 41 {
+		t.Errorf("unexpected career stats %+v", cs)
+	}
+
+	players := res.Data.Players
+	if len(players) != 2 || players[0].Stats == nil || len(players[0].Stats.QuickPlayStats.TopHeroes) != 1 {
+		t.Fatalf("unexpected players %+v", players)
+	}
+
+	if players[1].Stats != nil || players[1].Error == nil || players[1].Error.Code != "player_not_found" || players[1].Error.Status != http.StatusNotFound {
+		t.Errorf("expected the second player not to be found, got %+v", players[1])
+	}
+
+	if len(res.Data.Search) != 1 || res.Data.Search[0].BattleTag != "Viz#1213" {
+		t.Errorf("unexpected search %+v", res.Data.Search)
+	}
+
+	// Both lookups of Viz were served by the one scrape
+	if n := up.profileRequests(); n != 1 {
+		t.Errorf("expected 1 profile request, got %d", n)
+	}
+}
+
+func TestGraphQLRateLimit(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+	cfg.AnonymousRate = 0.01
+	cfg.AnonymousBurst = 3
+
+	e := EchoWithConfig(cfg)
+
+	// The request pays for the first lookup, the other two tokens of the
+	// burst for the next two
+	query := `{ players(platform: "pc", tags: ["Nobody-1", "Nobody-2", "Nobody-3", "Nobody-4"]) { tag error { code status } } }`
+	body, _ := json.Marshal(map[string]string{"query": query})
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var res struct {
+		Data struct {
+			Players []struct {
+				Tag   string
+				Error struct {
+					Code   string
+					Status int
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"player_not_found", "player_not_found", "player_not_found", "rate_limited"}
+	if len(res.Data.Players) != len(want) {
+		t.Fatalf("unexpected players %+v", res.Data.Players)
+	}
+	for i, p := range res.Data.Players {
+		if p.Error.Code != want[i] {
+			t.Errorf("%s: expected %s, got %+v", p.Tag, want[i], p.Error)
+		}
+	}
+
+	if h := rec.Header(); h.Get(headerRateLimitRemaining) != "0" || h.Get("Retry-After") == "" {
+		t.Errorf("expected the rate limit to be used up, got remaining %q retry after %q",
+			h.Get(headerRateLimitRemaining), h.Get("Retry-After"))
+	}
+
+	// Searches are charged like player lookups, here from the bucket of
+	// another IP with the same burst
+	query = `{ a: search(name: "Viz") { battleTag } b: search(name: "Viz") { battleTag } c: search(name: "Viz") { battleTag } d: search(name: "Viz") { battleTag } }`
+	body, _ = json.Marshal(map[string]string{"query": query})
+
+	req = httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "192.0.2.2:1234"
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var search struct {
+		Errors []struct {
+			Extensions struct{ Code string }
+		}
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &search); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fields may resolve in any order, so which search is limited varies
+	if len(search.Errors) != 1 || search.Errors[0].Extensions.Code != "rate_limited" {
+		t.Errorf("expected a single search to be rate limited, got %+v", search.Errors)
+	}
+}
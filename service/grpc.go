@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ow-api/ovrstat/ovrstat"
+	"github.com/ow-api/ovrstat/ovrstatpb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxBatchLookups bounds how many players a single BatchGetStats call may
+	// look up, like maxGraphQLLookups does for GraphQL
+	maxBatchLookups = 10
+
+	// Metadata keys of the gRPC API, named after their HTTP headers
+	mdAPIKey        = "x-api-key"
+	mdAuthorization = "authorization"
+	mdRetryAfter    = "retry-after"
+)
+
+// grpcServer implements the Ovrstat gRPC service on top of the server, sharing
+// its cache, lookups and rate limits with the REST API
+type grpcServer struct {
+	ovrstatpb.UnimplementedOvrstatServer
+	s *server
+}
+
+// newGRPCServer creates and returns a new gRPC server serving the Ovrstat
+// service, held to the same rate limits as the REST API
+func (s *server) newGRPCServer() *grpc.Server {
+	g := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.grpcRateLimit(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.grpcRateLimit(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	)
+	ovrstatpb.RegisterOvrstatServer(g, &grpcServer{s: s})
+	return g
+}
+
+// GetStats returns the stats of a player on a single platform
+func (gs *grpcServer) GetStats(ctx context.Context, req *ovrstatpb.GetStatsRequest) (*ovrstatpb.PlayerStats, error) {
+	stats, err := gs.s.lookupStatsContext(ctx, req.GetPlatform(), req.GetTag())
+	if err != nil {
+		return nil, gs.s.grpcErr(ctx, err)
+	}
+	return playerStatsToProto(stats), nil
+}
+
+// SearchPlayers returns every player matching a name
+func (gs *grpcServer) SearchPlayers(ctx context.Context, req *ovrstatpb.SearchPlayersRequest) (*ovrstatpb.SearchPlayersResponse, error) {
+	players, err := gs.s.client.SearchContext(ctx, req.GetName())
+	if err != nil {
+		return nil, gs.s.grpcErr(ctx, err)
+	}
+
+	res := &ovrstatpb.SearchPlayersResponse{}
+	for _, p := range players {
+		res.Players = append(res.Players, &ovrstatpb.Player{
+			BattleTag: p.BattleTag,
+			Portrait:  p.Portrait,
+			Frame:     p.Frame,
+			IsPublic:  p.IsPublic,
+			Url:       p.URL,
+		})
+	}
+	return res, nil
+}
+
+// BatchGetStats looks up the stats of several players concurrently, streaming
+// each result as soon as its lookup completes. Failed lookups are streamed as
+// errors rather than failing the whole call
+func (gs *grpcServer) BatchGetStats(req *ovrstatpb.BatchGetStatsRequest, stream ovrstatpb.Ovrstat_BatchGetStatsServer) error {
+	tags := req.GetTags()
+	if len(tags) > maxBatchLookups {
+		return status.Errorf(codes.InvalidArgument, "A single batch may look up at most %d players", maxBatchLookups)
+	}
+
+	ctx := stream.Context()
+
+	id, t, err := gs.s.grpcClient(ctx)
+	if err != nil {
+		return gs.s.grpcErr(ctx, err)
+	}
+
+	results := make(chan *ovrstatpb.BatchGetStatsResponse, len(tags))
+	for i, tag := range tags {
+		// Every lookup costs a request of the rate limit, the call itself
+		// paying for the first
+		if i > 0 && t.rate > 0 && !gs.s.limits.take(id, t, time.Now()).allowed {
+			results <- &ovrstatpb.BatchGetStatsResponse{
+				Tag: tag,
+				Result: &ovrstatpb.BatchGetStatsResponse_Error{Error: &ovrstatpb.Error{
+					Code:       "rate_limited",
+					Message:    "Too many requests, try again later",
+					HttpStatus: http.StatusTooManyRequests,
+				}},
+			}
+			continue
+		}
+
+		go func(tag string) {
+			res := &ovrstatpb.BatchGetStatsResponse{Tag: tag}
+
+			stats, err := gs.s.lookupStatsContext(ctx, req.GetPlatform(), tag)
+			if err != nil {
+				p := gs.s.grpcProblem(err)
+				res.Result = &ovrstatpb.BatchGetStatsResponse_Error{Error: &ovrstatpb.Error{
+					Code:       p.Code,
+					Message:    p.Detail,
+					HttpStatus: int32(p.Status),
+				}}
+			} else {
+				res.Result = &ovrstatpb.BatchGetStatsResponse_Stats{Stats: playerStatsToProto(stats)}
+			}
+
+			results <- res
+		}(tag)
+	}
+
+	// Responses are sent from this goroutine only, as a stream doesn't
+	// support concurrent sends
+	for range tags {
+		select {
+		case res := <-results:
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return nil
+}
+
+// grpcRateLimit holds a gRPC call to the rate limit of its client, identified
+// by the x-api-key or authorization metadata like REST requests are by their
+// headers
+func (s *server) grpcRateLimit(ctx context.Context) error {
+	id, t, err := s.grpcClient(ctx)
+	if err != nil {
+		return s.grpcErr(ctx, err)
+	}
+
+	if t.rate <= 0 {
+		return nil
+	}
+
+	res := s.limits.take(id, t, time.Now())
+	if !res.allowed {
+		setGRPCRetryAfter(ctx, res.retryAfter)
+		return status.Error(codes.ResourceExhausted, "Too many requests, try again later")
+	}
+	return nil
+}
+
+// grpcClient returns the id and tier of the client making a gRPC call
+func (s *server) grpcClient(ctx context.Context) (string, tier, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	key := firstMD(md, mdAPIKey)
+	if key == "" {
+		key = bearerToken(firstMD(md, mdAuthorization))
+	}
+
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	return s.limits.identifyKey(key, ip)
+}
+
+// firstMD returns the first value of a metadata key, empty when it's missing
+func firstMD(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// grpcErr maps an error to the gRPC status matching the problem the REST API
+// would serve for it, setting the retry-after header when upstream asked to
+// wait
+func (s *server) grpcErr(ctx context.Context, err error) error {
+	p := s.grpcProblem(err)
+	setGRPCRetryAfter(ctx, retryAfter(err))
+	return status.Error(grpcCode(p.Status), p.Detail)
+}
+
+// grpcProblem maps an error to the problem the REST API would serve for it,
+// logging internal errors like the error handler does
+func (s *server) grpcProblem(err error) *problem {
+	var p *problem
+	if !errors.As(err, &p) {
+		p = lookupProblem(err)
+	}
+
+	if p.internal != nil || p.Status >= http.StatusInternalServerError {
+		s.logger.Warnf("gRPC lookup failed: %s", p)
+	}
+	return p
+}
+
+// setGRPCRetryAfter sends the passed wait in whole seconds, rounded up, in the
+// retry-after header of a call. Nothing is sent for a wait of 0
+func setGRPCRetryAfter(ctx context.Context, d time.Duration) {
+	if d > 0 {
+		// The header can only fail to send once the call is over, when
+		// it's of no use anyway
+		_ = grpc.SetHeader(ctx, metadata.Pairs(mdRetryAfter, strconv.Itoa(ceilSeconds(d))))
+	}
+}
+
+// grpcCode returns the gRPC status code closest to an HTTP status
+func grpcCode(status int) codes.Code {
+	switch status {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
+
+// statKinds maps the kinds of career stats to their protobuf enum
+var statKinds = map[ovrstat.StatKind]ovrstatpb.StatKind{
+	ovrstat.StatCount:      ovrstatpb.StatKind_STAT_KIND_COUNT,
+	ovrstat.StatDuration:   ovrstatpb.StatKind_STAT_KIND_DURATION,
+	ovrstat.StatPercentage: ovrstatpb.StatKind_STAT_KIND_PERCENTAGE,
+	ovrstat.StatRatio:      ovrstatpb.StatKind_STAT_KIND_RATIO,
+	ovrstat.StatText:       ovrstatpb.StatKind_STAT_KIND_TEXT,
+}
+
+// playerStatsToProto converts player stats to their protobuf message
+func playerStatsToProto(stats *ovrstat.PlayerStats) *ovrstatpb.PlayerStats {
+	pb := &ovrstatpb.PlayerStats{
+		Icon:             stats.Icon,
+		Name:             stats.Name,
+		Endorsement:      int32(stats.Endorsement),
+		EndorsementIcon:  stats.EndorsementIcon,
+		GamesPlayed:      int32(stats.GamesPlayed),
+		GamesWon:         int32(stats.GamesWon),
+		GamesLost:        int32(stats.GamesLost),
+		QuickPlayStats:   statsCollectionToProto(&stats.QuickPlayStats.StatsCollection),
+		CompetitiveStats: statsCollectionToProto(&stats.CompetitiveStats.StatsCollection),
+		Private:          stats.Private,
+	}
+
+	if season := stats.CompetitiveStats.Season; season != nil {
+		pb.CompetitiveStats.Season = proto.Int32(int32(*season))
+	}
+
+	for _, r := range stats.Ratings {
+		pb.Ratings = append(pb.Ratings, &ovrstatpb.Rating{
+			Group:        r.Group,
+			Tier:         int32(r.Tier),
+			Role:         r.Role,
+			RoleIcon:     r.RoleIcon,
+			RankIcon:     r.RankIcon,
+			DivisionIcon: r.DivisionIcon,
+		})
+	}
+
+	for _, w := range stats.Warnings {
+		pb.Warnings = append(pb.Warnings, &ovrstatpb.ParseWarning{
+			Selector: w.Selector,
+			Field:    w.Field,
+			Reason:   w.Reason,
+		})
+	}
+
+	return pb
+}
+
+// statsCollectionToProto converts the stats of a play mode to their protobuf
+// message
+func statsCollectionToProto(sc *ovrstat.StatsCollection) *ovrstatpb.StatsCollection {
+	pb := &ovrstatpb.StatsCollection{
+		TopHeroes:   make(map[string]*ovrstatpb.TopHeroStats, len(sc.TopHeroes)),
+		CareerStats: make(map[string]*ovrstatpb.CareerStats, len(sc.CareerStats)),
+	}
+
+	for hero, th := range sc.TopHeroes {
+		if th == nil {
+			continue
+		}
+		pb.TopHeroes[hero] = &ovrstatpb.TopHeroStats{
+			TimePlayed:          th.TimePlayed,
+			TimePlayedSeconds:   th.TimePlayedSeconds,
+			GamesWon:            int32(th.GamesWon),
+			WeaponAccuracy:      int32(th.WeaponAccuracy),
+			CriticalHitAccuracy: int32(th.CriticalHitAccuracy),
+			EliminationsPerLife: th.EliminationsPerLife,
+			MultiKillBest:       int32(th.MultiKillBest),
+			ObjectiveKills:      th.ObjectiveKills,
+
+			WeaponAccuracyPercent:      th.WeaponAccuracyPercent,
+			CriticalHitAccuracyPercent: th.CriticalHitAccuracyPercent,
+		}
+	}
+
+	for hero, cs := range sc.CareerStats {
+		if cs == nil {
+			continue
+		}
+
+		categories := make(map[string]*ovrstatpb.StatCategory)
+		for category, values := range cs.Typed() {
+			stats := make(map[string]*ovrstatpb.StatValue, len(values))
+			for key, v := range values {
+				stats[key] = &ovrstatpb.StatValue{
+					Kind:    statKinds[v.Kind],
+					Value:   v.Value,
+					Display: v.Display,
+				}
+			}
+			categories[category] = &ovrstatpb.StatCategory{Stats: stats}
+		}
+		pb.CareerStats[hero] = &ovrstatpb.CareerStats{Categories: categories}
+	}
+
+	return pb
+}
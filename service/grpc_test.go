@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ow-api/ovrstat/ovrstatpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGRPC serves the gRPC API with the passed config over an in-memory
+// listener and returns a client connected to it
+func dialGRPC(t *testing.T, cfg Config) ovrstatpb.OvrstatClient {
+	g := newServer(cfg).newGRPCServer()
+
+	lis := bufconn.Listen(1 << 20)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return ovrstatpb.NewOvrstatClient(conn)
+}
+
+func TestGRPC(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	client := dialGRPC(t, cfg)
+	ctx := context.Background()
+
+	stats, err := client.GetStats(ctx, &ovrstatpb.GetStatsRequest{Platform: "pc", Tag: "Viz-1213"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.GetName() != "Viz" || stats.GetGamesWon() != 382 || stats.GetCompetitiveStats().Season == nil {
+		t.Errorf("unexpected stats %s %d %v", stats.GetName(), stats.GetGamesWon(), stats.GetCompetitiveStats().Season)
+	}
+
+	best := stats.GetCompetitiveStats().GetCareerStats()["allHeroes"].GetCategories()["best"].GetStats()["eliminationsMostInGame"]
+	if best.GetKind() != ovrstatpb.StatKind_STAT_KIND_COUNT || best.GetValue() != 41 {
+		t.Errorf("unexpected career stat %v", best)
+	}
+
+	_, err = client.GetStats(ctx, &ovrstatpb.GetStatsRequest{Platform: "pc", Tag: "Viz Bad-1213"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an invalid argument, got %v", err)
+	}
+
+	search, err := client.SearchPlayers(ctx, &ovrstatpb.SearchPlayersRequest{Name: "Viz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(search.GetPlayers()) != 1 || search.GetPlayers()[0].GetBattleTag() != "Viz#1213" {
+		t.Errorf("unexpected search results %v", search.GetPlayers())
+	}
+
+	stream, err := client.BatchGetStats(ctx, &ovrstatpb.BatchGetStatsRequest{
+		Platform: "pc",
+		Tags:     []string{"Viz-1213", "Nobody-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(map[string]*ovrstatpb.BatchGetStatsResponse)
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		results[res.GetTag()] = res
+	}
+
+	if results["Viz-1213"].GetStats().GetName() != "Viz" {
+		t.Errorf("expected the stats of Viz-1213, got %v", results["Viz-1213"])
+	}
+	if e := results["Nobody-1"].GetError(); e.GetCode() != "player_not_found" || e.GetHttpStatus() != 404 {
+		t.Errorf("expected Nobody-1 not to be found, got %v", results["Nobody-1"])
+	}
+
+	// Lookups share the cache of the REST API
+	if n := up.profileRequests(); n != 1 {
+		t.Errorf("expected a single career page request, got %d", n)
+	}
+
+	stream, err = client.BatchGetStats(ctx, &ovrstatpb.BatchGetStatsRequest{
+		Platform: "pc",
+		Tags:     make([]string, maxBatchLookups+1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an oversized batch to be rejected, got %v", err)
+	}
+}
+
+func TestGRPCBatchRateLimit(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+	cfg.AnonymousRate = 0.01
+	cfg.AnonymousBurst = 3
+
+	client := dialGRPC(t, cfg)
+
+	// The call pays for the first lookup, the other two tokens of the burst
+	// for the next two
+	stream, err := client.BatchGetStats(context.Background(), &ovrstatpb.BatchGetStatsRequest{
+		Platform: "pc",
+		Tags:     []string{"Nobody-1", "Nobody-2", "Nobody-3", "Nobody-4"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[res.GetTag()] = res.GetError().GetCode()
+	}
+
+	want := map[string]string{
+		"Nobody-1": "player_not_found",
+		"Nobody-2": "player_not_found",
+		"Nobody-3": "player_not_found",
+		"Nobody-4": "rate_limited",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
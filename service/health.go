@@ -0,0 +1,24 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+// health is the JSON form of the service health
+type health struct {
+	Status   string                `json:"status"`
+	Upstream ovrstat.BreakerStatus `json:"upstream"`
+}
+
+// healthcheck reports the service as healthy along with the state of the
+// circuit breaker guarding Blizzard. An open breaker doesn't make the service
+// unhealthy, cached stats are still served
+func (s *server) healthcheck(c echo.Context) error {
+	return c.JSON(http.StatusOK, health{
+		Status:   "ok",
+		Upstream: s.client.BreakerStatus(),
+	})
+}
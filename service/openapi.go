@@ -0,0 +1,20 @@
+package service
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// openAPISpec is the OpenAPI 3 document describing the REST API. It's kept in
+// sync with the registered routes by TestOpenAPIRoutes
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// openAPI serves the OpenAPI document of the REST API, rendered by the docs
+// page at /docs.html
+func openAPI(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, openAPISpec)
+}
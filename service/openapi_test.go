@@ -0,0 +1,176 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+// openAPIDoc is the part of the OpenAPI document the tests check
+type openAPIDoc struct {
+	Paths map[string]map[string]struct {
+		Parameters []struct {
+			Ref string `json:"$ref"`
+		} `json:"parameters"`
+	} `json:"paths"`
+	Components struct {
+		Parameters map[string]struct {
+			Name string `json:"name"`
+			In   string `json:"in"`
+		} `json:"parameters"`
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadOpenAPI(t *testing.T) *openAPIDoc {
+	e := Echo()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var doc openAPIDoc
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return &doc
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	doc := loadOpenAPI(t)
+	pathParam := regexp.MustCompile(`\{(\w+)\}`)
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		params := make(map[string]bool)
+		for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+			params[m[1]] = true
+		}
+
+		for method, op := range item {
+			documented[strings.ToUpper(method)+" "+pathParam.ReplaceAllString(path, ":$1")] = true
+
+			// Every path parameter must be described, and nothing else
+			// may claim to be one
+			found := make(map[string]bool)
+			for _, ref := range op.Parameters {
+				p := doc.Components.Parameters[strings.TrimPrefix(ref.Ref, "#/components/parameters/")]
+				if p.In == "path" {
+					found[p.Name] = true
+				}
+			}
+			if !reflect.DeepEqual(params, found) {
+				t.Errorf("%s %s: expected path parameters %v, got %v", method, path, params, found)
+			}
+		}
+	}
+
+	registered := make(map[string]bool)
+	for _, r := range Echo().Routes() {
+		// The static content isn't part of the API
+		if r.Path == "/*" {
+			continue
+		}
+		registered[r.Method+" "+r.Path] = true
+	}
+
+	for route := range registered {
+		if !documented[route] {
+			t.Errorf("route %s isn't described by openapi.json", route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			t.Errorf("openapi.json describes %s, which isn't registered", route)
+		}
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	schemas := map[string]reflect.Type{
+		"PlayerStats":      reflect.TypeOf(ovrstat.PlayerStats{}),
+		"ProfileStats":     reflect.TypeOf(ovrstat.ProfileStats{}),
+		"Rating":           reflect.TypeOf(ovrstat.Rating{}),
+		"QuickPlayStats":   reflect.TypeOf(ovrstat.QuickPlayStatsCollection{}),
+		"CompetitiveStats": reflect.TypeOf(ovrstat.CompetitiveStatsCollection{}),
+		"TopHeroStats":     reflect.TypeOf(ovrstat.TopHeroStats{}),
+		"StatValue":        reflect.TypeOf(ovrstat.StatValue{}),
+		"CareerStats":      reflect.TypeOf(ovrstat.CareerStats{}),
+		"ParseWarning":     reflect.TypeOf(ovrstat.ParseWarning{}),
+		"Player":           reflect.TypeOf(ovrstat.Player{}),
+		"Summary":          reflect.TypeOf(summary{}),
+		"HeroOverview":     reflect.TypeOf(heroOverview{}),
+		"HeroModeStats":    reflect.TypeOf(heroModeStats{}),
+		"HeroStats":        reflect.TypeOf(heroStats{}),
+		"Problem":          reflect.TypeOf(problem{}),
+		"GraphQLRequest":   reflect.TypeOf(graphqlRequest{}),
+		"Health":           reflect.TypeOf(health{}),
+		"BreakerStatus":    reflect.TypeOf(ovrstat.BreakerStatus{}),
+		"DriftReport":      reflect.TypeOf(driftReport{}),
+		"DriftCount":       reflect.TypeOf(driftCount{}),
+		"LookupStats":      reflect.TypeOf(flightStats{}),
+	}
+
+	for name, typ := range schemas {
+		schema, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("openapi.json has no %s schema", name)
+			continue
+		}
+
+		var props []string
+		for p := range schema.Properties {
+			props = append(props, p)
+		}
+		sort.Strings(props)
+
+		if fields := jsonFieldNames(typ); !reflect.DeepEqual(props, fields) {
+			t.Errorf("%s: expected properties %v, got %v", name, fields, props)
+		}
+	}
+}
+
+// jsonFieldNames returns the sorted names of every field in the JSON form of
+// the passed struct type, looking into embedded structs like encoding/json does
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFieldNames(f.Type)...)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if tag == "" {
+			tag = f.Name
+		}
+		names = append(names, tag)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// headerAPIKey carries the API key of a request, which may also be sent as
+	// a bearer token
+	headerAPIKey = "X-API-Key"
+
+	// Rate limit headers, as described by the IETF RateLimit header fields
+	// draft: the burst allowed, the requests left of it and the seconds until
+	// it's fully replenished
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+
+	// limiterIdleTimeout is how long the limiter of a client that stopped
+	// making requests is kept for
+	limiterIdleTimeout = 10 * time.Minute
+)
+
+// tier is the rate limit a client is held to, rate requests per second on
+// average with bursts of up to burst requests. A rate of 0 is unlimited
+type tier struct {
+	rate  float64
+	burst int
+}
+
+// rateLimiter holds API clients to their tier, anonymous clients per IP and
+// clients with an API key per key
+type rateLimiter struct {
+	keys      map[string]*apiKey
+	anonymous tier
+	keyed     tier
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// clientLimiter is the token bucket of a single client
+type clientLimiter struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+// limitResult is the outcome of taking a request from a client's bucket
+type limitResult struct {
+	allowed    bool
+	remaining  int
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+// newRateLimiter creates and returns a new rateLimiter with the API keys and
+// tiers of the passed config
+func newRateLimiter(cfg Config) (*rateLimiter, error) {
+	keys, err := loadAPIKeys(cfg.APIKeysFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// A key only setting its rate falls back to the default burst, which
+	// must then allow a request
+	for _, k := range keys {
+		if k.Rate > 0 && k.Burst == 0 && cfg.KeyBurst <= 0 {
+			return nil, errors.Errorf("API key %q sets no burst and the default key burst is %d", k.Name, cfg.KeyBurst)
+		}
+	}
+
+	return &rateLimiter{
+		keys:      keys,
+		anonymous: tier{rate: cfg.AnonymousRate, burst: cfg.AnonymousBurst},
+		keyed:     tier{rate: cfg.KeyRate, burst: cfg.KeyBurst},
+		clients:   make(map[string]*clientLimiter),
+	}, nil
+}
+
+// ipExtractor returns how the IP of a request is extracted, from the
+// X-Forwarded-For header when it was sent through one of the passed trusted
+// proxies and from the connection otherwise
+func ipExtractor(proxies []string) (echo.IPExtractor, error) {
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// Only the listed proxies are trusted, not every private network
+	opts := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, p := range proxies {
+		// Single addresses are ranges of their own
+		cidr := p
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid trusted proxy %q", p)
+		}
+		opts = append(opts, echo.TrustIPRange(ipnet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(opts...), nil
+}
+
+// identify returns the id and tier of the client making a request. Requests
+// carrying an unknown API key are rejected
+func (rl *rateLimiter) identify(c echo.Context) (string, tier, error) {
+	key := c.Request().Header.Get(headerAPIKey)
+	if key == "" {
+		key = bearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+	}
+	return rl.identifyKey(key, c.RealIP())
+}
+
+// identifyKey returns the id and tier of the client with the passed API key,
+// or of the passed IP when there's none
+func (rl *rateLimiter) identifyKey(key, ip string) (string, tier, error) {
+	// Keys are ignored altogether when none are configured
+	if key == "" || len(rl.keys) == 0 {
+		return "ip:" + ip, rl.anonymous, nil
+	}
+
+	k, ok := rl.keys[key]
+	if !ok {
+		return "", tier{}, newProblem(http.StatusUnauthorized, "invalid_api_key", "Unknown API key")
+	}
+
+	t := rl.keyed
+	if k.Rate > 0 {
+		t.rate = k.Rate
+
+		// Keys only setting their rate keep the default burst
+		if k.Burst > 0 {
+			t.burst = k.Burst
+		}
+	}
+	return "key:" + k.Key, t, nil
+}
+
+// bearerToken returns the token of a bearer Authorization header, empty for
+// any other scheme
+func bearerToken(auth string) string {
+	if strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// take takes a request from the bucket of the passed client, creating it when
+// the client hasn't been seen recently
+func (rl *rateLimiter) take(id string, t tier, now time.Time) limitResult {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.sweep(now)
+
+	cl, ok := rl.clients[id]
+	if !ok {
+		cl = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(t.rate), t.burst)}
+		rl.clients[id] = cl
+	}
+	cl.seen = now
+
+	res := limitResult{allowed: true}
+
+	r := cl.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		res.allowed = false
+	} else if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		res.allowed = false
+		res.retryAfter = delay
+	}
+
+	tokens := cl.limiter.TokensAt(now)
+	res.remaining = int(math.Max(0, math.Floor(tokens)))
+	res.reset = time.Duration((float64(t.burst) - tokens) / t.rate * float64(time.Second))
+
+	return res
+}
+
+// sweep drops the limiters of clients that stopped making requests, at most
+// once per idle timeout
+func (rl *rateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < limiterIdleTimeout {
+		return
+	}
+	rl.lastSweep = now
+
+	for id, cl := range rl.clients {
+		if now.Sub(cl.seen) > limiterIdleTimeout {
+			delete(rl.clients, id)
+		}
+	}
+}
+
+// rateLimit is the middleware holding API requests to the rate limit of their
+// client, reporting it in the RateLimit headers
+func (s *server) rateLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, t, err := s.limits.identify(c)
+		if err != nil {
+			return err
+		}
+
+		if t.rate <= 0 {
+			return next(c)
+		}
+
+		res := s.limits.take(id, t, time.Now())
+		setRateLimitHeaders(c.Response().Header(), t, res)
+
+		if !res.allowed {
+			return newProblem(http.StatusTooManyRequests, "rate_limited", "Too many requests, try again later")
+		}
+
+		return next(c)
+	}
+}
+
+// setRateLimitHeaders reports the outcome of taking a request from a client's
+// bucket in the RateLimit headers, along with a Retry-After header when it
+// wasn't allowed
+func setRateLimitHeaders(h http.Header, t tier, res limitResult) {
+	h.Set(headerRateLimitLimit, strconv.Itoa(t.burst))
+	h.Set(headerRateLimitRemaining, strconv.Itoa(res.remaining))
+	h.Set(headerRateLimitReset, strconv.Itoa(ceilSeconds(res.reset)))
+
+	if !res.allowed && res.retryAfter > 0 {
+		h.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(res.retryAfter)))
+	}
+}
+
+// ceilSeconds returns the passed duration in whole seconds, rounded up
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Second - 1) / time.Second)
+}
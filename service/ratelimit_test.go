@@ -0,0 +1,156 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRateLimit(t *testing.T) {
+	keys := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(keys, []byte(`[{"key":"secret","name":"test","rate":1,"burst":5},{"key":"rated","name":"rated","rate":1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig
+	cfg.APIKeysFile = keys
+	cfg.AnonymousRate = 0.01
+	cfg.AnonymousBurst = 2
+
+	e := EchoWithConfig(cfg)
+
+	// An invalid battletag fails without any upstream request
+	const path = "/stats/pc/Viz%20Bad-1213"
+
+	tests := []struct {
+		name      string
+		key       string
+		ip        string
+		forwarded string
+		status    int
+		limit     string
+		remaining string
+	}{
+		{"anonymous", "", "192.0.2.1", "", http.StatusBadRequest, "2", "1"},
+		{"anonymous", "", "192.0.2.1", "", http.StatusBadRequest, "2", "0"},
+		{"anonymous over limit", "", "192.0.2.1", "", http.StatusTooManyRequests, "2", "0"},
+		{"anonymous spoofed ip", "", "192.0.2.1", "198.51.100.1", http.StatusTooManyRequests, "2", "0"},
+		{"anonymous other ip", "", "192.0.2.2", "", http.StatusBadRequest, "2", "1"},
+		{"keyed", "secret", "192.0.2.1", "", http.StatusBadRequest, "5", "4"},
+		{"bearer", "Bearer secret", "192.0.2.1", "", http.StatusBadRequest, "5", "3"},
+		{"rate only", "rated", "192.0.2.1", "", http.StatusBadRequest, "50", "49"},
+		{"unknown key", "guess", "192.0.2.1", "", http.StatusUnauthorized, "", ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = tt.ip + ":1234"
+		if tt.forwarded != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwarded)
+		}
+		if strings.HasPrefix(tt.key, "Bearer ") {
+			req.Header.Set("Authorization", tt.key)
+		} else if tt.key != "" {
+			req.Header.Set(headerAPIKey, tt.key)
+		}
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		h := rec.Header()
+		if rec.Code != tt.status || h.Get(headerRateLimitLimit) != tt.limit || h.Get(headerRateLimitRemaining) != tt.remaining {
+			t.Errorf("%s: got %d limit %q remaining %q, want %d %q %q", tt.name, rec.Code,
+				h.Get(headerRateLimitLimit), h.Get(headerRateLimitRemaining), tt.status, tt.limit, tt.remaining)
+		}
+
+		if rec.Code == http.StatusTooManyRequests && (h.Get("Retry-After") == "" || h.Get(headerRateLimitReset) == "") {
+			t.Errorf("%s: expected Retry-After and RateLimit-Reset headers", tt.name)
+		}
+	}
+}
+
+func TestRateLimitConfig(t *testing.T) {
+	keys := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(keys, []byte(`[{"key":"rated","name":"rated","rate":1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		cfg   func(*Config)
+		valid bool
+	}{
+		{"default", func(*Config) {}, true},
+		{"disabled limits", func(c *Config) { c.AnonymousRate, c.AnonymousBurst = 0, 0 }, true},
+		{"anonymous without burst", func(c *Config) { c.AnonymousBurst = 0 }, false},
+		{"key without burst", func(c *Config) { c.KeyBurst = 0 }, false},
+		{"upstream without burst", func(c *Config) { c.UpstreamBurst = 0 }, false},
+		{"negative rate", func(c *Config) { c.KeyRate = -1 }, false},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig
+		tt.cfg(&cfg)
+
+		if err := cfg.validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid %v, got %v", tt.name, tt.valid, err)
+		}
+	}
+
+	// A key setting only its rate needs a default burst to fall back to
+	cfg := DefaultConfig
+	cfg.APIKeysFile = keys
+	cfg.KeyRate, cfg.KeyBurst = 0, 0
+
+	if _, err := newRateLimiter(cfg); err == nil {
+		t.Error("expected a key without any burst to fail")
+	}
+
+	if err := os.WriteFile(keys, []byte(`[{"key":"rated","name":"rated","rate":1,"burst":-1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadAPIKeys(keys); err == nil {
+		t.Error("expected a negative burst to fail")
+	}
+}
+
+func TestIPExtractor(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxies   []string
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{"direct", nil, "192.0.2.1", "198.51.100.1", "192.0.2.1"},
+		{"direct private", nil, "10.0.0.1", "198.51.100.1", "10.0.0.1"},
+		{"trusted proxy", []string{"192.0.2.0/24"}, "192.0.2.1", "198.51.100.1", "198.51.100.1"},
+		{"trusted proxy address", []string{"192.0.2.1"}, "192.0.2.1", "198.51.100.1", "198.51.100.1"},
+		{"untrusted proxy", []string{"192.0.2.0/24"}, "10.0.0.1", "198.51.100.1", "10.0.0.1"},
+		{"spoofed chain", []string{"192.0.2.0/24"}, "192.0.2.1", "203.0.113.1, 198.51.100.1", "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		extract, err := ipExtractor(tt.proxies)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tt.remote + ":1234"
+		req.Header.Set(echo.HeaderXForwardedFor, tt.forwarded)
+
+		if ip := extract(req); ip != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, ip)
+		}
+	}
+
+	if _, err := ipExtractor([]string{"192.0.2.0/99"}); err == nil {
+		t.Error("expected an invalid range to fail")
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+const (
+	// Play modes served by the modes resource, named after their JSON fields
+	modeQuickPlay   = "quickPlay"
+	modeCompetitive = "competitive"
+
+	// allHeroes is the career stats key of the stats summed over every hero
+	allHeroes = "allHeroes"
+)
+
+// summary is the profile overview of a player, without any hero stats
+type summary struct {
+	Icon            string           `json:"icon"`
+	Name            string           `json:"name"`
+	Endorsement     int              `json:"endorsement"`
+	EndorsementIcon string           `json:"endorsementIcon"`
+	Ratings         []ovrstat.Rating `json:"ratings"`
+	GamesPlayed     int              `json:"gamesPlayed"`
+	GamesWon        int              `json:"gamesWon"`
+	GamesLost       int              `json:"gamesLost"`
+	Season          *int             `json:"season"`
+	Private         bool             `json:"private"`
+}
+
+// heroOverview is the top hero stats of a hero in every play mode
+type heroOverview struct {
+	QuickPlay   *ovrstat.TopHeroStats `json:"quickPlay,omitempty"`
+	Competitive *ovrstat.TopHeroStats `json:"competitive,omitempty"`
+}
+
+// heroModeStats is every stat of a hero in a single play mode
+type heroModeStats struct {
+	TopHero     *ovrstat.TopHeroStats `json:"topHero,omitempty"`
+	CareerStats *ovrstat.CareerStats  `json:"careerStats,omitempty"`
+}
+
+// heroStats is every stat of a hero in every play mode
+type heroStats struct {
+	Hero        string         `json:"hero"`
+	QuickPlay   *heroModeStats `json:"quickPlay,omitempty"`
+	Competitive *heroModeStats `json:"competitive,omitempty"`
+}
+
+// statsSummary serves the profile overview of a player
+func (s *server) statsSummary(c echo.Context) error {
+	stats, err := s.lookupStats(c, c.Param("platform"), c.Param("tag"))
+	if err != nil {
+		return lookupErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, &summary{
+		Icon:            stats.Icon,
+		Name:            stats.Name,
+		Endorsement:     stats.Endorsement,
+		EndorsementIcon: stats.EndorsementIcon,
+		Ratings:         ratingsOf(stats),
+		GamesPlayed:     stats.GamesPlayed,
+		GamesWon:        stats.GamesWon,
+		GamesLost:       stats.GamesLost,
+		Season:          stats.CompetitiveStats.Season,
+		Private:         stats.Private,
+	})
+}
+
+// statsRatings serves the competitive ratings of a player
+func (s *server) statsRatings(c echo.Context) error {
+	stats, err := s.lookupPublicStats(c)
+	if err != nil {
+		return lookupErr(c, err)
+	}
+	return c.JSON(http.StatusOK, ratingsOf(stats))
+}
+
+// statsHeroes serves the top hero stats of every hero a player has played,
+// without their career stats
+func (s *server) statsHeroes(c echo.Context) error {
+	stats, err := s.lookupPublicStats(c)
+	if err != nil {
+		return lookupErr(c, err)
+	}
+
+	heroes := make(map[string]*heroOverview)
+	for _, hero := range heroNames(stats) {
+		heroes[hero] = &heroOverview{
+			QuickPlay:   stats.QuickPlayStats.TopHeroes[hero],
+			Competitive: stats.CompetitiveStats.TopHeroes[hero],
+		}
+	}
+	return c.JSON(http.StatusOK, heroes)
+}
+
+// statsHero serves every stat of a single hero, matched regardless of case
+func (s *server) statsHero(c echo.Context) error {
+	stats, err := s.lookupPublicStats(c)
+	if err != nil {
+		return lookupErr(c, err)
+	}
+
+	for _, hero := range heroNames(stats) {
+		if !strings.EqualFold(hero, c.Param("hero")) {
+			continue
+		}
+
+		return c.JSON(http.StatusOK, &heroStats{
+			Hero:        hero,
+			QuickPlay:   heroModeOf(&stats.QuickPlayStats.StatsCollection, hero),
+			Competitive: heroModeOf(&stats.CompetitiveStats.StatsCollection, hero),
+		})
+	}
+	return newProblem(http.StatusNotFound, "hero_not_found", "The player has no stats for this hero")
+}
+
+// statsMode serves every stat of a single play mode, matched regardless of
+// case
+func (s *server) statsMode(c echo.Context) error {
+	stats, err := s.lookupPublicStats(c)
+	if err != nil {
+		return lookupErr(c, err)
+	}
+
+	switch mode := c.Param("mode"); {
+	case strings.EqualFold(mode, modeQuickPlay):
+		return c.JSON(http.StatusOK, &stats.QuickPlayStats)
+	case strings.EqualFold(mode, modeCompetitive):
+		return c.JSON(http.StatusOK, &stats.CompetitiveStats)
+	}
+	return newProblem(http.StatusNotFound, "mode_not_found", "Unknown play mode, expected quickPlay or competitive")
+}
+
+// lookupPublicStats returns the stats of the player in the request path,
+// failing with ovrstat.ErrPlayerPrivate when the profile is private as none of
+// the stats are shown
+func (s *server) lookupPublicStats(c echo.Context) (*ovrstat.PlayerStats, error) {
+	stats, err := s.lookupStats(c, c.Param("platform"), c.Param("tag"))
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.Private {
+		return nil, ovrstat.ErrPlayerPrivate
+	}
+	return stats, nil
+}
+
+// ratingsOf returns the ratings of a player, never nil so they're always
+// served as an array
+func ratingsOf(stats *ovrstat.PlayerStats) []ovrstat.Rating {
+	if stats.Ratings == nil {
+		return []ovrstat.Rating{}
+	}
+	return stats.Ratings
+}
+
+// heroNames returns the sorted name of every hero with stats in any play mode
+func heroNames(stats *ovrstat.PlayerStats) []string {
+	seen := make(map[string]bool)
+
+	for _, sc := range []*ovrstat.StatsCollection{
+		&stats.QuickPlayStats.StatsCollection,
+		&stats.CompetitiveStats.StatsCollection,
+	} {
+		for hero := range sc.TopHeroes {
+			seen[hero] = true
+		}
+		for hero := range sc.CareerStats {
+			if hero != allHeroes {
+				seen[hero] = true
+			}
+		}
+	}
+
+	var names []string
+	for hero := range seen {
+		names = append(names, hero)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// heroModeOf returns the stats of a hero in a play mode, nil when the hero
+// wasn't played in it
+func heroModeOf(sc *ovrstat.StatsCollection, hero string) *heroModeStats {
+	hm := &heroModeStats{
+		TopHero:     sc.TopHeroes[hero],
+		CareerStats: sc.CareerStats[hero],
+	}
+
+	if hm.TopHero == nil && hm.CareerStats == nil {
+		return nil
+	}
+	return hm
+}
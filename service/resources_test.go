@@ -0,0 +1,67 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatsResources(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	tests := []struct {
+		path   string
+		status int
+		check  func(body map[string]interface{}) bool
+	}{
+		{"/stats/pc/Viz-1213/summary", http.StatusOK, func(b map[string]interface{}) bool {
+			_, heroes := b["quickPlayStats"]
+			return b["name"] != nil && b["season"] == 9.0 && !heroes
+		}},
+		{"/stats/pc/Viz-1213/ratings", http.StatusOK, nil},
+		{"/stats/pc/Viz-1213/heroes", http.StatusOK, func(b map[string]interface{}) bool {
+			ana, _ := b["ana"].(map[string]interface{})
+			_, allHeroes := b["allHeroes"]
+			return len(b) == 3 && ana["quickPlay"] != nil && ana["competitive"] != nil && !allHeroes
+		}},
+		{"/stats/pc/Viz-1213/heroes/Ana", http.StatusOK, func(b map[string]interface{}) bool {
+			qp, _ := b["quickPlay"].(map[string]interface{})
+			return b["hero"] == "ana" && qp["topHero"] != nil && qp["careerStats"] != nil
+		}},
+		{"/stats/pc/Viz-1213/heroes/genji", http.StatusNotFound, nil},
+		{"/stats/pc/Viz-1213/modes/competitive", http.StatusOK, func(b map[string]interface{}) bool {
+			return b["season"] == 9.0 && b["topHeroes"] != nil
+		}},
+		{"/stats/pc/Viz-1213/modes/arcade", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.status, rec.Code)
+			continue
+		}
+
+		if tt.check == nil {
+			continue
+		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || !tt.check(body) {
+			t.Errorf("%s: unexpected body %s", tt.path, rec.Body)
+		}
+	}
+
+	// Every resource is served from the one cached scrape
+	if n := up.profileRequests(); n != 1 {
+		t.Errorf("expected 1 profile request, got %d", n)
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// search handles searching for every player matching a name and serving the
+// candidates in JSON
+func (s *server) search(c echo.Context) error {
+	players, err := s.client.SearchContext(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return lookupErr(c, err)
+	}
+	return c.JSON(http.StatusOK, players)
+}
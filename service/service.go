@@ -2,10 +2,14 @@ package service
 
 import (
 	"embed"
+	"net"
 	"net/http"
+	"sync"
 
+	"github.com/graphql-go/graphql"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/ow-api/ovrstat/ovrstat"
 )
 
 //go:embed static/*
@@ -13,22 +17,124 @@ var staticFS embed.FS
 
 // Start starts serving the service on the passed port
 func Start(port string) {
-	e := Echo()
+	cfg := DefaultConfig
+	cfg.Port = port
+	StartWithConfig(cfg)
+}
+
+// StartWithConfig starts serving the service with the passed config, along
+// with the gRPC API when a gRPC port is set
+func StartWithConfig(cfg Config) {
+	s := newServer(cfg)
+	e := s.echo
+
+	if cfg.GRPCPort != "" {
+		g := s.newGRPCServer()
+		e.Server.RegisterOnShutdown(g.GracefulStop)
+
+		go func() {
+			lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+			if err != nil {
+				e.Logger.Fatal(err)
+			}
+			e.Logger.Fatal(g.Serve(lis))
+		}()
+	}
+
 	// Listen on the specified port
-	e.Logger.Fatal(e.Start(":" + port))
+	e.Logger.Fatal(e.Start(":" + cfg.Port))
+}
+
+// server holds the state shared by the service handlers
+type server struct {
+	cfg     Config
+	client  *ovrstat.Client
+	cache   cacheBackend
+	drift   *driftMonitor
+	flights *flightGroup
+	limits  *rateLimiter
+	schema  graphql.Schema
+	echo    *echo.Echo
+	logger  echo.Logger
+
+	mu         sync.Mutex
+	refreshing map[string]bool
 }
 
 // Echo creates and returns a new echo Echo for the service
 func Echo() *echo.Echo {
+	return EchoWithConfig(DefaultConfig)
+}
+
+// EchoWithConfig creates and returns a new echo Echo for the service with the
+// passed config
+func EchoWithConfig(cfg Config) *echo.Echo {
+	return newServer(cfg).echo
+}
+
+// newServer creates and returns a new server with the passed config, serving
+// the REST API on its echo Echo
+func newServer(cfg Config) *server {
+	s := &server{
+		cfg:        cfg,
+		client:     cfg.Client,
+		drift:      newDriftMonitor(),
+		flights:    newFlightGroup(),
+		refreshing: make(map[string]bool),
+	}
+
 	// Create a new echo Echo and bind all middleware
 	e := echo.New()
 	e.HideBanner = true
+	e.HTTPErrorHandler = errorHandler
+	s.echo = e
+	s.logger = e.Logger
+
+	if err := cfg.validate(); err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	if s.client == nil && cfg.UpstreamRate > 0 {
+		s.client = ovrstat.NewClient(
+			ovrstat.WithRateLimit(cfg.UpstreamRate, cfg.UpstreamBurst),
+			ovrstat.WithQueueTimeout(cfg.UpstreamQueueTimeout),
+		)
+	} else if s.client == nil {
+		s.client = ovrstat.DefaultClient
+	}
+
+	// Open the cache, closed along with the server
+	cache, err := newCacheBackend(cfg)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	s.cache = cache
+	e.Server.RegisterOnShutdown(func() {
+		if err := cache.close(); err != nil {
+			e.Logger.Error(err)
+		}
+	})
+
+	// Clients are told apart by IP, which can only be taken from the
+	// X-Forwarded-For header of trusted proxies
+	if e.IPExtractor, err = ipExtractor(cfg.TrustedProxies); err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	if s.limits, err = newRateLimiter(cfg); err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	if s.schema, err = s.newGraphQLSchema(); err != nil {
+		e.Logger.Fatal(err)
+	}
 
 	// Bind middleware
 	e.Pre(middleware.RemoveTrailingSlashWithConfig(
 		middleware.TrailingSlashConfig{
 			RedirectCode: http.StatusPermanentRedirect,
 		}))
+	e.Use(middleware.RequestID())
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Pre(middleware.Secure())
@@ -39,10 +145,20 @@ func Echo() *echo.Echo {
 	e.GET("/*", echo.WrapHandler(http.FileServer(http.FS(staticFS))),
 		middleware.Rewrite(map[string]string{"/*": "/static/$1"}))
 
-	// Handle stats API requests
-	e.GET("/stats/:platform/:tag", stats)
-	e.GET("/healthcheck", func(c echo.Context) error {
-		return c.NoContent(http.StatusOK)
-	})
-	return e
+	// Handle stats API requests, held to the rate limit of the client
+	e.GET("/stats/:tag", s.allStats, s.rateLimit)
+	e.GET("/stats/:platform/:tag", s.stats, s.rateLimit)
+	e.GET("/stats/:platform/:tag/summary", s.statsSummary, s.rateLimit)
+	e.GET("/stats/:platform/:tag/ratings", s.statsRatings, s.rateLimit)
+	e.GET("/stats/:platform/:tag/heroes", s.statsHeroes, s.rateLimit)
+	e.GET("/stats/:platform/:tag/heroes/:hero", s.statsHero, s.rateLimit)
+	e.GET("/stats/:platform/:tag/modes/:mode", s.statsMode, s.rateLimit)
+	e.GET("/search/:name", s.search, s.rateLimit)
+	e.GET("/graphql", s.graphql, s.rateLimit)
+	e.POST("/graphql", s.graphql, s.rateLimit)
+	e.GET("/debug/drift", s.debugDrift)
+	e.GET("/debug/lookups", s.debugLookups)
+	e.GET("/healthcheck", s.healthcheck)
+	e.GET("/openapi.json", openAPI)
+	return s
 }
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ow-api/ovrstat/ovrstat"
+)
+
+func TestProblemResponses(t *testing.T) {
+	e := Echo()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		code   string
+	}{
+		{http.MethodGet, "/stats/pc/Viz%20Bad-1213", http.StatusBadRequest, "invalid_battletag"},
+		{http.MethodPost, "/stats/pc/Viz-1213", http.StatusMethodNotAllowed, "method_not_allowed"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.status, rec.Code)
+		}
+
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, mimeProblemJSON) {
+			t.Errorf("%s: expected a problem content type, got %q", tt.path, ct)
+		}
+
+		var p problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		if p.Code != tt.code || p.Status != tt.status || p.Type != problemTypeBase+strings.Replace(tt.code, "_", "-", -1) {
+			t.Errorf("%s: unexpected problem %+v", tt.path, p)
+		}
+
+		if p.RequestID == "" || p.RequestID != rec.Header().Get("X-Request-Id") {
+			t.Errorf("%s: expected the request id in the problem, got %q", tt.path, p.RequestID)
+		}
+	}
+}
+
+// upstream is a stand-in for the Overwatch site serving the search API and the
+// career page fixture of the ovrstat package
+type upstream struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	page     []byte
+	players  []byte
+	profiles int
+	failing  bool
+}
+
+// newUpstream starts a new upstream, closed when the test finishes. Viz#1213
+// is the only player it knows until setPlayers is called. While failing it
+// drops every connection
+func newUpstream(t *testing.T) *upstream {
+	page, err := os.ReadFile(filepath.Join("..", "ovrstat", "testdata", "profile.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up := &upstream{
+		page:    page,
+		players: []byte(`[{"battleTag":"Viz#1213","isPublic":true,"url":"viz-1213"}]`),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/", func(w http.ResponseWriter, r *http.Request) {
+		up.mu.Lock()
+		failing, players := up.failing, up.players
+		up.mu.Unlock()
+
+		if failing {
+			panic(http.ErrAbortHandler)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasPrefix(strings.ToLower(strings.TrimPrefix(r.URL.Path, "/search/")), "viz") {
+			w.Write(players)
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("/career/", func(w http.ResponseWriter, r *http.Request) {
+		up.mu.Lock()
+		up.profiles++
+		page := up.page
+		up.mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+
+	up.Server = httptest.NewServer(mux)
+	t.Cleanup(up.Close)
+
+	return up
+}
+
+// client returns a new ovrstat.Client performing lookups against the upstream
+func (up *upstream) client() *ovrstat.Client {
+	return ovrstat.NewClient(
+		ovrstat.WithHTTPClient(up.Client()),
+		ovrstat.WithSearchURL(up.URL+"/search/"),
+		ovrstat.WithCareerURL(up.URL+"/career"),
+	)
+}
+
+// profileRequests returns how many career pages have been requested
+func (up *upstream) profileRequests() int {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	return up.profiles
+}
+
+// setPage replaces the career page the upstream serves with the result of
+// passing the fixture to edit
+func (up *upstream) setPage(edit func(page string) string) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.page = []byte(edit(string(up.page)))
+}
+
+// setPlayers replaces the search results served for names starting with viz
+func (up *upstream) setPlayers(players string) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.players = []byte(players)
+}
+
+// setFailing sets whether the upstream drops every connection
+func (up *upstream) setFailing(failing bool) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.failing = failing
+}
+
+func TestMarkupChanged(t *testing.T) {
+	up := newUpstream(t)
+
+	// Simulate Blizzard renaming the classes the career page is recognised by
+	up.setPage(strings.NewReplacer(
+		"Profile-masthead", "Profile-header",
+		"Profile-player--filters", "Profile-player--platforms",
+	).Replace)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/pc/Viz-1213", nil))
+
+		var p problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		if rec.Code != http.StatusBadGateway || p.Code != "markup_changed" {
+			t.Errorf("expected a markup change, got %d %s", rec.Code, p.Code)
+		}
+	}
+
+	// Unlike a missing player, a markup change isn't cached
+	if n := up.profileRequests(); n != 2 {
+		t.Errorf("expected 2 profile requests, got %d", n)
+	}
+}
+
+func TestPlatformNotFound(t *testing.T) {
+	up := newUpstream(t)
+
+	// Simulate a player who only ever played on pc
+	up.setPage(func(page string) string {
+		return strings.Replace(page, `<div class="Profile-player--filter" id="controllerFilter">Console</div>`, "", 1)
+	})
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/console/Viz-1213", nil))
+
+	var p problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Code != http.StatusNotFound || p.Code != "platform_not_found" {
+		t.Errorf("expected the platform not to be found, got %d %s", rec.Code, p.Code)
+	}
+}
+
+func TestAllStats(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+
+	e := EchoWithConfig(cfg)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/Viz-1213", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var profile ovrstat.ProfileStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &profile); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(profile.Platforms, ",") != "console,pc" {
+		t.Errorf("expected the console and pc platforms, got %v", profile.Platforms)
+	}
+
+	for _, platform := range profile.Platforms {
+		if stats := profile.Stats[platform]; stats == nil || stats.Name != "Viz" {
+			t.Errorf("expected the %s stats of Viz, got %+v", platform, stats)
+		}
+	}
+
+	// Both platforms come from one page, which is cached for the next request
+	if n := up.profileRequests(); n != 1 {
+		t.Errorf("expected a single career page fetch, got %d", n)
+	}
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/Viz-1213?fields=name,competitiveStats.season", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var got, want interface{}
+	json.Unmarshal(rec.Body.Bytes(), &got)
+	json.Unmarshal([]byte(`{"platforms":["console","pc"],"private":false,"stats":{`+
+		`"console":{"competitiveStats":{"season":9},"name":"Viz"},`+
+		`"pc":{"competitiveStats":{"season":9},"name":"Viz"}}}`), &want)
+
+	if mustJSON(got) != mustJSON(want) {
+		t.Errorf("expected the fields of every platform, got %s", rec.Body)
+	}
+
+	if n := up.profileRequests(); n != 1 {
+		t.Errorf("expected the selection to be served from the cache, got %d fetches", n)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = up.client()
+	cfg.AnonymousRate = 0
+
+	e := EchoWithConfig(cfg)
+
+	search := func(name string) []ovrstat.Player {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search/"+name, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", name, rec.Code)
+		}
+
+		var players []ovrstat.Player
+		if err := json.Unmarshal(rec.Body.Bytes(), &players); err != nil {
+			t.Fatal(err)
+		}
+		return players
+	}
+
+	if players := search("Viz"); len(players) != 1 || players[0].BattleTag != "Viz#1213" {
+		t.Errorf("expected Viz#1213, got %+v", players)
+	}
+
+	// A second Viz makes the name alone ambiguous
+	up.setPlayers(`[{"battleTag":"Viz#1213","isPublic":true,"url":"viz-1213"},{"battleTag":"Viz#2222","isPublic":true,"url":"viz-2222"}]`)
+
+	if players := search("Viz"); len(players) != 2 {
+		t.Errorf("expected both players, got %+v", players)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/pc/Viz", nil))
+
+	var p problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Code != http.StatusConflict || p.Code != "ambiguous_player" {
+		t.Errorf("expected an ambiguous player, got %d %s", rec.Code, p.Code)
+	}
+
+	// The full battletag still picks out a single account
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/pc/Viz-1213", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected the full battletag to be found, got %d", rec.Code)
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	up := newUpstream(t)
+
+	cfg := DefaultConfig
+	cfg.Client = ovrstat.NewClient(
+		ovrstat.WithHTTPClient(up.Client()),
+		ovrstat.WithSearchURL(up.URL+"/search/"),
+		ovrstat.WithCareerURL(up.URL+"/career"),
+		ovrstat.WithRateLimit(0.5, 1),
+		ovrstat.WithQueueTimeout(0),
+	)
+
+	e := EchoWithConfig(cfg)
+
+	// The search of the first lookup uses up the burst, so its profile request
+	// can't be queued
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/pc/Viz-1213", nil))
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get(echo.HeaderRetryAfter) != "2" {
+		t.Errorf("expected a 503 retrying after 2 seconds, got %d %q", rec.Code, rec.Header().Get(echo.HeaderRetryAfter))
+	}
+
+	var p problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil || p.Code != "upstream_queue_full" {
+		t.Errorf("expected an upstream_queue_full problem, got %s", rec.Body)
+	}
+}
+
+func TestHealthcheck(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Echo().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+
+	var h health
+	if err := json.Unmarshal(rec.Body.Bytes(), &h); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Code != http.StatusOK || h.Status != "ok" || h.Upstream.State != ovrstat.BreakerClosed {
+		t.Errorf("expected a healthy service with a closed breaker, got %d %s", rec.Code, rec.Body)
+	}
+}
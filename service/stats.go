@@ -1,23 +1,150 @@
 package service
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/ow-api/ovrstat/ovrstat"
-	"github.com/pkg/errors"
 )
 
 // stats handles retrieving and serving Overwatch stats in JSON
-func stats(c echo.Context) error {
-	// Perform a full player stats lookup
-	stats, err := ovrstat.Stats(c.Param("platform"), c.Param("tag"))
+func (s *server) stats(c echo.Context) error {
+	sel, err := parseSelection(c)
 	if err != nil {
-		if err == ovrstat.ErrPlayerNotFound {
-			return newErr(http.StatusNotFound, "Player not found")
+		return err
+	}
+
+	stats, err := s.lookupStats(c, c.Param("platform"), c.Param("tag"))
+	if err != nil {
+		return lookupErr(c, err)
+	}
+
+	if sel == nil {
+		return c.JSON(http.StatusOK, stats)
+	}
+
+	selected, err := sel.apply(stats)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, selected)
+}
+
+// allStats handles retrieving and serving the Overwatch stats of every
+// platform on a players profile in JSON
+func (s *server) allStats(c echo.Context) error {
+	sel, err := parseSelection(c)
+	if err != nil {
+		return err
+	}
+
+	profile, err := s.lookupProfile(c, c.Param("tag"))
+	if err != nil {
+		return lookupErr(c, err)
+	}
+
+	if sel == nil {
+		return c.JSON(http.StatusOK, profile)
+	}
+
+	// The selection applies to the stats of every platform
+	selected := make(map[string]interface{}, len(profile.Stats))
+	for platform, stats := range profile.Stats {
+		if selected[platform], err = sel.apply(stats); err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, &struct {
+		*ovrstat.ProfileStats
+		Stats map[string]interface{} `json:"stats"`
+	}{profile, selected})
+}
+
+// lookupStats returns the stats of a single platform, from the cache when
+// possible
+func (s *server) lookupStats(c echo.Context, platform, tag string) (*ovrstat.PlayerStats, error) {
+	key, fetch, err := s.statsLookup(platform, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.cachedLookup(c, key, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.Stats, nil
+}
+
+// lookupStatsContext returns the stats of a single platform like lookupStats,
+// for callers without a request of their own such as the GraphQL resolvers
+func (s *server) lookupStatsContext(ctx context.Context, platform, tag string) (*ovrstat.PlayerStats, error) {
+	key, fetch, err := s.statsLookup(platform, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, _, err := s.lookup(ctx, key, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.Stats, nil
+}
+
+// statsLookup returns the cache key and fetch of a single platform lookup
+func (s *server) statsLookup(platform, tag string) (string, fetchFunc, error) {
+	// The same platform is used for the key and the fetch, so the response
+	// never depends on what happens to be cached
+	platform, err := normalizePlatform(platform)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key, err := cacheKey("stats", platform, tag)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key, func(ctx context.Context) (*cacheEntry, error) {
+		// Perform a full player stats lookup, abandoned once every caller
+		// waiting on it disconnects
+		stats, err := s.client.StatsContext(ctx, platform, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		s.recordDrift(stats.Drift)
+
+		return &cacheEntry{Stats: stats}, nil
+	}, nil
+}
+
+// lookupProfile returns the stats of every platform on a profile, from the
+// cache when possible
+func (s *server) lookupProfile(c echo.Context, tag string) (*ovrstat.ProfileStats, error) {
+	key, err := cacheKey("profile", "", tag)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.cachedLookup(c, key, func(ctx context.Context) (*cacheEntry, error) {
+		// Perform a full profile lookup, abandoned once every caller waiting on
+		// it disconnects
+		profile, err := s.client.AllStatsContext(ctx, tag)
+		if err != nil {
+			return nil, err
 		}
-		return newErr(http.StatusInternalServerError,
-			errors.Wrap(err, "Failed to retrieve player stats"))
+
+		s.recordDrift(profileDrift(profile))
+
+		return &cacheEntry{Profile: profile}, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return c.JSON(http.StatusOK, stats)
+
+	return entry.Profile, nil
 }